@@ -3,21 +3,34 @@ package dependencies
 import (
 	"travel_advisor/districts/repository"
 	"travel_advisor/domain"
+	forecastRepository "travel_advisor/forecasts/repository"
 	"travel_advisor/pkg/cache"
 	"travel_advisor/pkg/conn"
 )
 
 type RepositoryInterfaces struct {
-	Districts domain.DistrictRepository
-	Cacher    cache.Cache
+	Districts      domain.DistrictRepository
+	Observations   domain.ObservationRepository
+	Backfill       domain.BackfillProgressRepository
+	ClimateNormals domain.ClimateNormalRepository
+	Forecasts      domain.ForecastSnapshotRepository
+	Cacher         cache.Cache
 }
 
 func InjectRepositories() RepositoryInterfaces {
 	db := conn.DefaultDB()
 	districRepository := repository.NewDistrictPostgreSQL(db)
+	observationRepository := repository.NewObservationPostgreSQL(db)
+	backfillRepository := repository.NewBackfillProgressPostgreSQL(db)
+	climateNormalRepository := repository.NewClimateNormalPostgreSQL(db)
+	forecastSnapshotRepository := forecastRepository.NewForecastSnapshotPostgreSQL(db)
 	cacher := conn.DefaultCache()
 	return RepositoryInterfaces{
-		Districts: districRepository,
-		Cacher:    cacher,
+		Districts:      districRepository,
+		Observations:   observationRepository,
+		Backfill:       backfillRepository,
+		ClimateNormals: climateNormalRepository,
+		Forecasts:      forecastSnapshotRepository,
+		Cacher:         cacher,
 	}
 }
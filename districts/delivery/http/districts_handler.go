@@ -0,0 +1,268 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"travel_advisor/districts/transformer"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type DistrictHandler struct {
+	DistrictUsecase domain.DistrictUsecase
+}
+
+func NewDistrictHandler(r *chi.Mux, d domain.DistrictUsecase) {
+	handler := &DistrictHandler{
+		DistrictUsecase: d,
+	}
+	r.Route("/v1/districts", func(r chi.Router) {
+		r.Use(helpers.JWTAuthMiddleware)
+		r.Get("/", handler.List)
+		r.Get("/locate", handler.Locate)
+		r.Get("/{id}", handler.Get)
+		r.Get("/{id}/history", handler.History)
+		r.Get("/{id}/forecast", handler.Forecast)
+	})
+}
+
+func (h *DistrictHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	districts, err := h.DistrictUsecase.List(ctx)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusInternalServerError,
+			Message: "districts fetch failed",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	if helpers.AcceptsGeoJSON(r) {
+		resp := &helpers.GeoJSONResponse{
+			Status: http.StatusOK,
+			Data:   transformer.TransformDistrictFeatureCollection(districts),
+		}
+		resp.Render(w)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   transformer.TransformDistrictListResponse(districts),
+	}
+	resp.Render(w)
+}
+
+func (h *DistrictHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid district id",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	district, err := h.DistrictUsecase.Get(ctx, id)
+	if err != nil {
+		renderDistrictError(w, err)
+		return
+	}
+
+	if helpers.AcceptsGeoJSON(r) {
+		resp := &helpers.GeoJSONResponse{
+			Status: http.StatusOK,
+			Data:   transformer.TransformDistrictFeature(district, nil),
+		}
+		resp.Render(w)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   transformer.TransformDistrictResponse(district),
+	}
+	resp.Render(w)
+}
+
+func (h *DistrictHandler) Locate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	lat, errLat := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	long, errLong := strconv.ParseFloat(r.URL.Query().Get("long"), 64)
+	if errLat != nil || errLong != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "lat and long query parameters are required",
+		}
+		resp.Render(w)
+		return
+	}
+
+	district, err := h.DistrictUsecase.Locate(ctx, lat, long)
+	if err != nil {
+		renderDistrictError(w, err)
+		return
+	}
+
+	if helpers.AcceptsGeoJSON(r) {
+		resp := &helpers.GeoJSONResponse{
+			Status: http.StatusOK,
+			Data:   transformer.TransformDistrictFeature(district, nil),
+		}
+		resp.Render(w)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   transformer.TransformDistrictResponse(district),
+	}
+	resp.Render(w)
+}
+
+func (h *DistrictHandler) History(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid district id",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	ctr, err := parseHistoryCriteria(r, id, time.Now())
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid history query",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	history, err := h.DistrictUsecase.History(ctx, ctr)
+	if err != nil {
+		renderDistrictError(w, err)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   transformer.TransformHistoryResponse(history, ctr),
+	}
+	resp.Render(w)
+}
+
+func (h *DistrictHandler) Forecast(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid district id",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	forecast, err := h.DistrictUsecase.Forecast(ctx, id)
+	if err != nil {
+		renderDistrictError(w, err)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   transformer.TransformForecastResponse(forecast),
+	}
+	resp.Render(w)
+}
+
+// parseHistoryCriteria reads from, to and granularity. from and to accept a
+// date (to is then inclusive) or an RFC3339 timestamp; the range defaults to
+// the last seven days at hourly granularity.
+func parseHistoryCriteria(r *http.Request, districtID int64, now time.Time) (*domain.ObservationCriteria, error) {
+	q := r.URL.Query()
+	ctr := &domain.ObservationCriteria{
+		DistrictID:  districtID,
+		To:          now,
+		Granularity: domain.GranularityHour,
+		TimeZone:    config.App().Timezone,
+	}
+	if g := q.Get("granularity"); g != "" {
+		ctr.Granularity = g
+	}
+
+	loc, err := time.LoadLocation(ctr.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	if v := q.Get("to"); v != "" {
+		t, dateOnly, err := parseHistoryTime(v, loc)
+		if err != nil {
+			return nil, err
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		ctr.To = t
+	}
+	ctr.From = ctr.To.AddDate(0, 0, -7)
+	if v := q.Get("from"); v != "" {
+		t, _, err := parseHistoryTime(v, loc)
+		if err != nil {
+			return nil, err
+		}
+		ctr.From = t
+	}
+	return ctr, nil
+}
+
+func parseHistoryTime(v string, loc *time.Location) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, v, loc); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid time %q, expected YYYY-MM-DD or RFC3339", v)
+	}
+	return t, false, nil
+}
+
+func renderDistrictError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrDistrictNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidGranularity), errors.Is(err, domain.ErrInvalidTimeRange):
+		status = http.StatusBadRequest
+	}
+	resp := &helpers.Response{
+		Status:  status,
+		Message: "district fetch failed",
+		Error:   err.Error(),
+	}
+	resp.Render(w)
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/geo"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock DistrictUsecase
+type MockDistrictUsecase struct {
+	mock.Mock
+}
+
+func (m *MockDistrictUsecase) List(ctx context.Context) ([]*domain.District, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*domain.District), args.Error(1)
+}
+
+func (m *MockDistrictUsecase) Get(ctx context.Context, id int64) (*domain.District, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.District), args.Error(1)
+}
+
+func (m *MockDistrictUsecase) Locate(ctx context.Context, lat, long float64) (*domain.District, error) {
+	args := m.Called(ctx, lat, long)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.District), args.Error(1)
+}
+
+func (m *MockDistrictUsecase) History(ctx context.Context, ctr *domain.ObservationCriteria) (*domain.DistrictHistory, error) {
+	args := m.Called(ctx, ctr)
+	return args.Get(0).(*domain.DistrictHistory), args.Error(1)
+}
+
+func (m *MockDistrictUsecase) Forecast(ctx context.Context, id int64) (*domain.DistrictForecast, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*domain.DistrictForecast), args.Error(1)
+}
+
+func TestDistrictHandler_GeoJSON(t *testing.T) {
+	dhaka := &domain.District{
+		ID: 47, Name: "Dhaka", Lat: 23.7, Long: 90.4,
+		Boundary: geo.MultiPolygon{geo.Polygon{
+			geo.Ring{{90.3, 23.6}, {90.5, 23.6}, {90.5, 23.8}, {90.3, 23.8}, {90.3, 23.6}},
+		}},
+	}
+	sylhet := &domain.District{ID: 36, Name: "Sylhet", Lat: 24.9, Long: 91.87}
+
+	tests := []struct {
+		name                string
+		path                string
+		accept              string
+		setupMocks          func(*MockDistrictUsecase)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        []string
+	}{
+		{
+			name:   "List as a feature collection",
+			path:   "/v1/districts",
+			accept: "application/geo+json",
+			setupMocks: func(mockUsecase *MockDistrictUsecase) {
+				mockUsecase.On("List", mock.Anything).Return([]*domain.District{dhaka, sylhet}, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: helpers.GeoJSONMediaType,
+			expectedBody:        []string{`"type":"FeatureCollection"`, `"type":"MultiPolygon"`, `"type":"Point"`},
+		},
+		{
+			name:   "List in the data envelope by default",
+			path:   "/v1/districts",
+			accept: "application/json",
+			setupMocks: func(mockUsecase *MockDistrictUsecase) {
+				mockUsecase.On("List", mock.Anything).Return([]*domain.District{dhaka, sylhet}, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        []string{`"data":`, `"name":"Sylhet"`},
+		},
+		{
+			name:   "Get as a feature among other media types",
+			path:   "/v1/districts/47",
+			accept: "application/json;q=0.5, application/geo+json",
+			setupMocks: func(mockUsecase *MockDistrictUsecase) {
+				mockUsecase.On("Get", mock.Anything, int64(47)).Return(dhaka, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: helpers.GeoJSONMediaType,
+			expectedBody:        []string{`"type":"Feature"`, `"type":"MultiPolygon"`, `"name":"Dhaka"`},
+		},
+		{
+			name:   "Locate without a boundary as a point feature",
+			path:   "/v1/districts/locate?lat=24.8&long=91.9",
+			accept: "application/geo+json",
+			setupMocks: func(mockUsecase *MockDistrictUsecase) {
+				mockUsecase.On("Locate", mock.Anything, 24.8, 91.9).Return(sylhet, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: helpers.GeoJSONMediaType,
+			expectedBody:        []string{`"type":"Feature"`, `"type":"Point"`, `"name":"Sylhet"`},
+		},
+		{
+			name:   "Error - Not found stays in the error envelope",
+			path:   "/v1/districts/99",
+			accept: "application/geo+json",
+			setupMocks: func(mockUsecase *MockDistrictUsecase) {
+				mockUsecase.On("Get", mock.Anything, int64(99)).Return(nil, domain.ErrDistrictNotFound)
+			},
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json",
+			expectedBody:        []string{`"error":"district not found"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockDistrictUsecase)
+			tt.setupMocks(mockUsecase)
+
+			handler := &DistrictHandler{
+				DistrictUsecase: mockUsecase,
+			}
+
+			router := chi.NewRouter()
+			router.Get("/v1/districts", handler.List)
+			router.Get("/v1/districts/locate", handler.Locate)
+			router.Get("/v1/districts/{id}", handler.Get)
+
+			r := httptest.NewRequest("GET", tt.path, nil)
+			r.Header.Set("Accept", tt.accept)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, r)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectedContentType, rr.Header().Get("Content-Type"))
+			for _, body := range tt.expectedBody {
+				assert.Contains(t, rr.Body.String(), body)
+			}
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
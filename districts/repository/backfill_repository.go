@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/conn"
+
+	"gorm.io/gorm"
+)
+
+type BackfillProgressPostgreSQL struct {
+	db *conn.DB
+}
+
+func NewBackfillProgressPostgreSQL(db *conn.DB) domain.BackfillProgressRepository {
+	return &BackfillProgressPostgreSQL{
+		db: db,
+	}
+}
+
+// CompletedDays returns the completed days between from and to, keyed by YYYY-MM-DD
+func (r *BackfillProgressPostgreSQL) CompletedDays(ctx context.Context, districtID int64, from, to time.Time) (map[string]bool, error) {
+	var days []time.Time
+	err := r.db.DB.WithContext(ctx).
+		Table("backfill_progress").
+		Where("district_id = ? AND day BETWEEN ? AND ?", districtID, from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Pluck("day", &days).Error
+	if err != nil {
+		return nil, fmt.Errorf("repository:postgreSQL: failed to fetch backfill progress: %v", err)
+	}
+
+	done := make(map[string]bool, len(days))
+	for _, d := range days {
+		done[d.Format(time.DateOnly)] = true
+	}
+	return done, nil
+}
+
+func (r *BackfillProgressPostgreSQL) MarkCompleted(ctx context.Context, districtID int64, days []time.Time) error {
+	stmt := `
+		INSERT INTO backfill_progress (district_id, day)
+		VALUES (?, ?)
+		ON CONFLICT (district_id, day) DO UPDATE SET completed_at = NOW();
+	`
+	return r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, d := range days {
+			if err := tx.Exec(stmt, districtID, d.Format(time.DateOnly)).Error; err != nil {
+				return fmt.Errorf("repository:postgreSQL: failed to mark backfill progress: %v", err)
+			}
+		}
+		return nil
+	})
+}
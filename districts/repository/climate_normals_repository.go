@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/conn"
+)
+
+type ClimateNormalPostgreSQL struct {
+	db *conn.DB
+}
+
+func NewClimateNormalPostgreSQL(db *conn.DB) domain.ClimateNormalRepository {
+	return &ClimateNormalPostgreSQL{
+		db: db,
+	}
+}
+
+// Recompute derives the daily 2PM temperature and mean PM2.5 of every district
+// day, then pools them by circular day-of-year distance
+func (r *ClimateNormalPostgreSQL) Recompute(ctx context.Context, windowDays int, timeZone string) (int64, error) {
+	stmt := `
+		WITH daily AS (
+			SELECT district_id,
+				(observed_at AT TIME ZONE @tz)::date AS day,
+				AVG(temperature_2m) FILTER (WHERE EXTRACT(HOUR FROM observed_at AT TIME ZONE @tz) = 14) AS temp_2pm,
+				AVG(pm2_5) AS pm25
+			FROM district_observations
+			GROUP BY 1, 2
+		), doys AS (
+			SELECT generate_series(1, 366) AS doy
+		)
+		INSERT INTO district_climate_normals
+			(district_id, day_of_year, temp_2pm_mean, temp_2pm_stddev, pm25_mean, pm25_stddev, sample_days, updated_at)
+		SELECT daily.district_id, doys.doy,
+			AVG(daily.temp_2pm), STDDEV_SAMP(daily.temp_2pm),
+			AVG(daily.pm25), STDDEV_SAMP(daily.pm25),
+			COUNT(*), NOW()
+		FROM daily
+		JOIN doys ON LEAST(
+			ABS(EXTRACT(DOY FROM daily.day) - doys.doy),
+			366 - ABS(EXTRACT(DOY FROM daily.day) - doys.doy)
+		) <= @window
+		GROUP BY 1, 2
+		ON CONFLICT (district_id, day_of_year) DO UPDATE SET
+			temp_2pm_mean = EXCLUDED.temp_2pm_mean,
+			temp_2pm_stddev = EXCLUDED.temp_2pm_stddev,
+			pm25_mean = EXCLUDED.pm25_mean,
+			pm25_stddev = EXCLUDED.pm25_stddev,
+			sample_days = EXCLUDED.sample_days,
+			updated_at = NOW();
+	`
+	res := r.db.DB.WithContext(ctx).Exec(stmt, map[string]interface{}{
+		"tz":     timeZone,
+		"window": windowDays,
+	})
+	if res.Error != nil {
+		return 0, fmt.Errorf("repository:postgreSQL: failed to recompute climate normals: %v", res.Error)
+	}
+	return res.RowsAffected, nil
+}
+
+// ListByDays returns the normals of every district for the days of the year,
+// keyed by day of the year and then by district id
+func (r *ClimateNormalPostgreSQL) ListByDays(ctx context.Context, daysOfYear []int) (map[int]map[int64]*domain.DistrictClimateNormal, error) {
+	var list []*domain.DistrictClimateNormal
+	if err := r.db.DB.WithContext(ctx).Where("day_of_year IN ?", daysOfYear).Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("repository:postgreSQL: failed to fetch climate normals: %v", err)
+	}
+
+	normals := make(map[int]map[int64]*domain.DistrictClimateNormal, len(daysOfYear))
+	for _, n := range list {
+		if normals[n.DayOfYear] == nil {
+			normals[n.DayOfYear] = make(map[int64]*domain.DistrictClimateNormal)
+		}
+		normals[n.DayOfYear][n.DistrictID] = n
+	}
+	return normals, nil
+}
@@ -22,11 +22,15 @@ func NewDistrictPostgreSQL(db *conn.DB) domain.DistrictRepository {
 func (r *DistrictPostgreSQL) List(ctx context.Context, ctr *domain.DistrictCriteria) ([]*domain.District, error) {
 	qry := r.db.DB.WithContext(ctx)
 
+	if ctr.ID != nil && *ctr.ID != 0 {
+		qry = qry.Where("id = ?", *ctr.ID)
+	}
+
 	if ctr.DistrictName != nil && *ctr.DistrictName != "" {
 		qry = qry.Where("name = ?", *ctr.DistrictName)
 	}
 	var districtList = make([]*domain.District, 0)
-	if err := qry.Find(&districtList).Error; err != nil {
+	if err := qry.Order("id").Find(&districtList).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrDistrictNotFound
 		}
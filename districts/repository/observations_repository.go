@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/conn"
+
+	"gorm.io/gorm/clause"
+)
+
+const observationBatchSize = 500
+
+type ObservationPostgreSQL struct {
+	db *conn.DB
+}
+
+func NewObservationPostgreSQL(db *conn.DB) domain.ObservationRepository {
+	return &ObservationPostgreSQL{
+		db: db,
+	}
+}
+
+// Upsert inserts the samples, overwriting any already stored for the same district and hour
+func (r *ObservationPostgreSQL) Upsert(ctx context.Context, obs []*domain.DistrictObservation) error {
+	if len(obs) == 0 {
+		return nil
+	}
+	err := r.db.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "district_id"}, {Name: "observed_at"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"temperature_2m", "relative_humidity_2m", "precipitation", "wind_speed_10m", "pm2_5", "pm10",
+			}),
+		}).
+		CreateInBatches(obs, observationBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("repository:postgreSQL: failed to upsert observations: %v", err)
+	}
+	return nil
+}
+
+func (r *ObservationPostgreSQL) History(ctx context.Context, ctr *domain.ObservationCriteria) ([]*domain.DistrictObservation, error) {
+	var (
+		list = make([]*domain.DistrictObservation, 0)
+		err  error
+	)
+
+	switch ctr.Granularity {
+	case domain.GranularityHour:
+		err = r.db.DB.WithContext(ctx).
+			Where("district_id = ? AND observed_at >= ? AND observed_at < ?", ctr.DistrictID, ctr.From, ctr.To).
+			Order("observed_at").
+			Find(&list).Error
+	case domain.GranularityDay:
+		tz := ctr.TimeZone
+		if tz == "" {
+			tz = "UTC"
+		}
+		err = r.db.DB.WithContext(ctx).Raw(`
+			SELECT district_id,
+				date_trunc('day', observed_at AT TIME ZONE @tz) AT TIME ZONE @tz AS observed_at,
+				AVG(temperature_2m) AS temperature_2m,
+				AVG(relative_humidity_2m) AS relative_humidity_2m,
+				SUM(precipitation) AS precipitation,
+				AVG(wind_speed_10m) AS wind_speed_10m,
+				AVG(pm2_5) AS pm2_5,
+				AVG(pm10) AS pm10
+			FROM district_observations
+			WHERE district_id = @district AND observed_at >= @from AND observed_at < @to
+			GROUP BY 1, 2
+			ORDER BY 2`,
+			map[string]interface{}{
+				"tz":       tz,
+				"district": ctr.DistrictID,
+				"from":     ctr.From,
+				"to":       ctr.To,
+			}).
+			Scan(&list).Error
+	default:
+		return nil, domain.ErrInvalidGranularity
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("repository:postgreSQL: failed to fetch observation history: %v", err)
+	}
+	return list, nil
+}
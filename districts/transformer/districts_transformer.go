@@ -0,0 +1,111 @@
+package transformer
+
+import (
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/geo"
+)
+
+type DistrictResponse struct {
+	ID          int64   `json:"id"`
+	DivisionID  int     `json:"division_id"`
+	Name        string  `json:"name"`
+	BnName      string  `json:"bn_name"`
+	Lat         float64 `json:"lat"`
+	Long        float64 `json:"long"`
+	HasBoundary bool    `json:"has_boundary"`
+}
+
+func TransformDistrictResponse(d *domain.District) DistrictResponse {
+	return DistrictResponse{
+		ID:          d.ID,
+		DivisionID:  d.DivisionID,
+		Name:        d.Name,
+		BnName:      d.BnName,
+		Lat:         d.Lat,
+		Long:        d.Long,
+		HasBoundary: !d.Boundary.IsEmpty(),
+	}
+}
+
+func TransformDistrictListResponse(ds []*domain.District) []DistrictResponse {
+	resp := make([]DistrictResponse, 0)
+	for _, d := range ds {
+		resp = append(resp, TransformDistrictResponse(d))
+	}
+	return resp
+}
+
+// TransformDistrictFeature renders a district as a GeoJSON feature. The
+// boundary is used as geometry when it was imported, the centroid otherwise.
+func TransformDistrictFeature(d *domain.District, props map[string]interface{}) geo.Feature {
+	geometry := geo.NewPointGeometry(d.Lat, d.Long)
+	if !d.Boundary.IsEmpty() {
+		geometry = geo.NewMultiPolygonGeometry(d.Boundary)
+	}
+
+	properties := map[string]interface{}{
+		"id":          d.ID,
+		"division_id": d.DivisionID,
+		"name":        d.Name,
+		"bn_name":     d.BnName,
+		"lat":         d.Lat,
+		"long":        d.Long,
+	}
+	for k, v := range props {
+		properties[k] = v
+	}
+
+	return geo.Feature{
+		Type:       geo.TypeFeature,
+		ID:         d.ID,
+		Geometry:   geometry,
+		Properties: properties,
+	}
+}
+
+func TransformDistrictFeatureCollection(ds []*domain.District) *geo.FeatureCollection {
+	fc := geo.NewFeatureCollection()
+	for _, d := range ds {
+		fc.Features = append(fc.Features, TransformDistrictFeature(d, nil))
+	}
+	return fc
+}
+
+type HistoryResponse struct {
+	District    DistrictResponse              `json:"district"`
+	Granularity string                        `json:"granularity"`
+	From        time.Time                     `json:"from"`
+	To          time.Time                     `json:"to"`
+	Points      []*domain.DistrictObservation `json:"points"`
+}
+
+func TransformHistoryResponse(h *domain.DistrictHistory, ctr *domain.ObservationCriteria) HistoryResponse {
+	obs := h.Observations
+	if obs == nil {
+		obs = make([]*domain.DistrictObservation, 0)
+	}
+	return HistoryResponse{
+		District:    TransformDistrictResponse(h.District),
+		Granularity: ctr.Granularity,
+		From:        ctr.From,
+		To:          ctr.To,
+		Points:      obs,
+	}
+}
+
+type ForecastResponse struct {
+	District DistrictResponse   `json:"district"`
+	Days     []*domain.DailySun `json:"days"`
+}
+
+func TransformForecastResponse(f *domain.DistrictForecast) ForecastResponse {
+	days := f.Days
+	if days == nil {
+		days = make([]*domain.DailySun, 0)
+	}
+	return ForecastResponse{
+		District: TransformDistrictResponse(f.District),
+		Days:     days,
+	}
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/conn"
+	"travel_advisor/pkg/geo"
+)
+
+// maxHistoryRange bounds a single history query
+const maxHistoryRange = 366 * 24 * time.Hour
+
+type DistrictUsecase struct {
+	DistrictsRepository    domain.DistrictRepository
+	ObservationsRepository domain.ObservationRepository
+}
+
+func NewDistrictUsecase(d domain.DistrictRepository, o domain.ObservationRepository) domain.DistrictUsecase {
+	return &DistrictUsecase{
+		DistrictsRepository:    d,
+		ObservationsRepository: o,
+	}
+}
+
+func (u *DistrictUsecase) List(ctx context.Context) ([]*domain.District, error) {
+	return u.DistrictsRepository.List(ctx, &domain.DistrictCriteria{})
+}
+
+func (u *DistrictUsecase) Get(ctx context.Context, id int64) (*domain.District, error) {
+	districts, err := u.DistrictsRepository.List(ctx, &domain.DistrictCriteria{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(districts) == 0 {
+		return nil, domain.ErrDistrictNotFound
+	}
+	return districts[0], nil
+}
+
+// Locate returns the district whose boundary contains the point. Districts
+// without an imported boundary, or points that fall outside every boundary
+// (offshore, border slivers), fall back to the nearest district centroid.
+func (u *DistrictUsecase) Locate(ctx context.Context, lat, long float64) (*domain.District, error) {
+	districts, err := u.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return LocateDistrict(districts, lat, long)
+}
+
+// LocateDistrict runs the point-in-polygon lookup against an already loaded district list
+func LocateDistrict(districts []*domain.District, lat, long float64) (*domain.District, error) {
+	for _, d := range districts {
+		if d.Boundary.Contains(lat, long) {
+			return d, nil
+		}
+	}
+
+	var nearest *domain.District
+	minDist := math.MaxFloat64
+	for _, d := range districts {
+		if dist := geo.Distance(lat, long, d.Lat, d.Long); dist < minDist {
+			minDist = dist
+			nearest = d
+		}
+	}
+	if nearest == nil {
+		return nil, domain.ErrDistrictNotFound
+	}
+	return nearest, nil
+}
+
+func (u *DistrictUsecase) History(ctx context.Context, ctr *domain.ObservationCriteria) (*domain.DistrictHistory, error) {
+	if ctr.Granularity != domain.GranularityHour && ctr.Granularity != domain.GranularityDay {
+		return nil, domain.ErrInvalidGranularity
+	}
+	if !ctr.From.Before(ctr.To) || ctr.To.Sub(ctr.From) > maxHistoryRange {
+		return nil, domain.ErrInvalidTimeRange
+	}
+	district, err := u.Get(ctx, ctr.DistrictID)
+	if err != nil {
+		return nil, err
+	}
+	if ctr.TimeZone == "" {
+		ctr.TimeZone = config.App().Timezone
+	}
+	obs, err := u.ObservationsRepository.History(ctx, ctr)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.DistrictHistory{District: district, Observations: obs}, nil
+}
+
+// Forecast returns the daily UV index, sunrise, sunset and daylight of the
+// district from today to the end of the forecast horizon
+func (u *DistrictUsecase) Forecast(ctx context.Context, id int64) (*domain.DistrictForecast, error) {
+	district, err := u.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(config.App().Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	from := time.Now().In(loc)
+	to := from.AddDate(0, 0, helpers.ForecastHorizonDays-1)
+
+	days, err := helpers.FetchDailySun(ctx, conn.GetHTTClient(), district.Lat, district.Long,
+		from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DistrictForecast{
+		District: district,
+		Days:     days,
+	}, nil
+}
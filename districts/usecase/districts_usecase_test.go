@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"testing"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocateDistrict(t *testing.T) {
+	// Dhaka's boundary reaches far east of its centroid, so a point there is
+	// nearer to the Narayanganj centroid but still inside Dhaka
+	dhaka := &domain.District{
+		ID: 47, Name: "Dhaka", Lat: 23.7, Long: 90.4,
+		Boundary: geo.MultiPolygon{geo.Polygon{
+			geo.Ring{{90.3, 23.6}, {90.9, 23.6}, {90.9, 23.8}, {90.3, 23.8}, {90.3, 23.6}},
+		}},
+	}
+	narayanganj := &domain.District{
+		ID: 40, Name: "Narayanganj", Lat: 23.7, Long: 90.95,
+		Boundary: geo.MultiPolygon{geo.Polygon{
+			geo.Ring{{90.9, 23.5}, {91.1, 23.5}, {91.1, 23.8}, {90.9, 23.8}, {90.9, 23.5}},
+		}},
+	}
+	// no boundary imported
+	sylhet := &domain.District{ID: 36, Name: "Sylhet", Lat: 24.9, Long: 91.87}
+	districts := []*domain.District{sylhet, dhaka, narayanganj}
+
+	tests := []struct {
+		name      string
+		districts []*domain.District
+		lat, long float64
+		expected  string
+	}{
+		{
+			name:      "Inside a boundary beats a nearer centroid",
+			districts: districts,
+			lat:       23.7, long: 90.85,
+			expected: "Dhaka",
+		},
+		{
+			name:      "Outside every boundary falls back to the nearest centroid",
+			districts: districts,
+			lat:       23.3, long: 90.9,
+			expected: "Narayanganj",
+		},
+		{
+			name:      "District without a boundary is found by its centroid",
+			districts: districts,
+			lat:       24.8, long: 91.9,
+			expected: "Sylhet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := LocateDistrict(tt.districts, tt.lat, tt.long)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, d.Name)
+		})
+	}
+
+	t.Run("Error - No districts", func(t *testing.T) {
+		_, err := LocateDistrict(nil, 23.7, 90.4)
+
+		assert.ErrorIs(t, err, domain.ErrDistrictNotFound)
+	})
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// BackfillProgressRepository remembers which district days were already
+// backfilled so an interrupted run can resume
+type BackfillProgressRepository interface {
+	CompletedDays(ctx context.Context, districtID int64, from, to time.Time) (map[string]bool, error)
+	MarkCompleted(ctx context.Context, districtID int64, days []time.Time) error
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"math"
+)
+
+// DistrictClimateNormal is the expected 2PM temperature and daily PM2.5 of a district
+// on a day of the year, with their standard deviations
+type DistrictClimateNormal struct {
+	DistrictID    int64    `json:"district_id"`
+	DayOfYear     int      `json:"day_of_year"`
+	Temp2PMMean   *float64 `json:"temp_2pm_mean" gorm:"column:temp_2pm_mean"`
+	Temp2PMStdDev *float64 `json:"temp_2pm_stddev" gorm:"column:temp_2pm_stddev"`
+	PM25Mean      *float64 `json:"pm25_mean" gorm:"column:pm25_mean"`
+	PM25StdDev    *float64 `json:"pm25_stddev" gorm:"column:pm25_stddev"`
+	SampleDays    int      `json:"sample_days"`
+}
+
+// ClimateAnomaly is how far a value is from its climate normal, in absolute
+// units and in standard deviations
+type ClimateAnomaly struct {
+	TempAnomaly *float64 `json:"temp_anomaly,omitempty"`
+	TempZScore  *float64 `json:"temp_z_score,omitempty"`
+	PM25Anomaly *float64 `json:"pm25_anomaly,omitempty"`
+	PM25ZScore  *float64 `json:"pm25_z_score,omitempty"`
+}
+
+// Anomaly compares temp and pm25 to the normal. Z-scores are left empty when
+// the normal has no spread.
+func (n *DistrictClimateNormal) Anomaly(temp, pm25 float64) *ClimateAnomaly {
+	if n == nil {
+		return nil
+	}
+	a := &ClimateAnomaly{}
+	a.TempAnomaly, a.TempZScore = deviation(temp, n.Temp2PMMean, n.Temp2PMStdDev)
+	a.PM25Anomaly, a.PM25ZScore = deviation(pm25, n.PM25Mean, n.PM25StdDev)
+	if a.TempAnomaly == nil && a.PM25Anomaly == nil {
+		return nil
+	}
+	return a
+}
+
+// MeanNormal averages the normals of several days, each field over the days
+// that have it. It is nil without normals.
+func MeanNormal(normals []*DistrictClimateNormal) *DistrictClimateNormal {
+	if len(normals) == 0 {
+		return nil
+	}
+	m := &DistrictClimateNormal{DistrictID: normals[0].DistrictID}
+	var temp, tempSD, pm25, pm25SD []*float64
+	for _, n := range normals {
+		temp, tempSD = append(temp, n.Temp2PMMean), append(tempSD, n.Temp2PMStdDev)
+		pm25, pm25SD = append(pm25, n.PM25Mean), append(pm25SD, n.PM25StdDev)
+		m.SampleDays += n.SampleDays
+	}
+	m.Temp2PMMean, m.Temp2PMStdDev = meanOf(temp), meanOf(tempSD)
+	m.PM25Mean, m.PM25StdDev = meanOf(pm25), meanOf(pm25SD)
+	return m
+}
+
+func meanOf(vals []*float64) *float64 {
+	var sum float64
+	var n int
+	for _, v := range vals {
+		if v != nil {
+			sum += *v
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	mean := sum / float64(n)
+	return &mean
+}
+
+func deviation(v float64, mean, stddev *float64) (*float64, *float64) {
+	if mean == nil {
+		return nil, nil
+	}
+	diff := v - *mean
+	if stddev == nil || *stddev == 0 || math.IsNaN(*stddev) {
+		return &diff, nil
+	}
+	z := diff / *stddev
+	return &diff, &z
+}
+
+type ClimateNormalRepository interface {
+	// Recompute rebuilds every normal from the stored observations, pooling
+	// the days within windowDays of each day of the year
+	Recompute(ctx context.Context, windowDays int, timeZone string) (int64, error)
+	// ListByDays keys the normals by day of the year and then by district id
+	ListByDays(ctx context.Context, daysOfYear []int) (map[int]map[int64]*DistrictClimateNormal, error)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "travel_advisor/pkg/aqi"
+
+// TravelConditions are the weather and air quality metrics of a place on a
+// travel date. Optional metrics are nil when the provider has no data for them.
+type TravelConditions struct {
+	Temp2PM                  float64  `json:"temp_2pm"`
+	PM25                     float64  `json:"pm25"`
+	Humidity2PM              *float64 `json:"humidity_2pm,omitempty"`
+	HeatIndex2PM             *float64 `json:"heat_index_2pm,omitempty"`
+	PrecipitationProbability *float64 `json:"precipitation_probability,omitempty"`
+	WindSpeed2PM             *float64 `json:"wind_speed_2pm,omitempty"`
+	PrecipitationSum         *float64 `json:"precipitation_sum,omitempty"`
+	// WeatherCode is the most severe WMO weather code of the day
+	WeatherCode *int `json:"weather_code,omitempty"`
+	// AQI is the air quality index of the day in the configured standard
+	AQI *aqi.Result `json:"aqi,omitempty"`
+	// UVIndexMax and DaylightHours come from the daily forecast, the
+	// archive has no UV index
+	UVIndexMax    *float64 `json:"uv_index_max,omitempty"`
+	DaylightHours *float64 `json:"daylight_hours,omitempty"`
+}
+
+// IsRainCode reports whether a WMO weather code is rain, showers or a
+// thunderstorm. Drizzle, fog and snow are not counted.
+func IsRainCode(code int) bool {
+	return (code >= 61 && code <= 67) || (code >= 80 && code <= 82) || code >= 95
+}
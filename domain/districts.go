@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"time"
+	"travel_advisor/pkg/aqi"
+	"travel_advisor/pkg/geo"
 )
 
 type DistrictResponse struct {
@@ -19,29 +21,158 @@ type DistrictJSON struct {
 	Long       string `json:"long"`
 }
 
+// DistrictCacheVersion is the current cache format. Entries of other
+// versions are ignored until the scheduler overwrites them.
+const DistrictCacheVersion = 3
+
+// MetricStats is the distribution of a metric over the aggregation window
+type MetricStats struct {
+	Avg float64 `json:"avg"`
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+	P90 float64 `json:"p90"`
+}
+
 type DistrictCache struct {
-	Name       string
-	AvgTemp2PM float64
-	AvgPM25    float64
+	Version int
+	Name    string
+	// UpdatedAt is when the entry was fetched, zero for entries written
+	// before it was recorded
+	UpdatedAt time.Time
+	// Temp, PM25 and the weather metrics below are reduced with Statistic
+	// over the configured hours, the stats describe the samples behind them
+	Statistic string `json:",omitempty"`
+	Temp      float64
+	PM25      float64
+	TempStats *MetricStats `json:",omitempty"`
+	PM25Stats *MetricStats `json:",omitempty"`
+	// extra weather variables and the metrics derived from them, nil when
+	// the provider has no data
+	Humidity     *float64 `json:",omitempty"`
+	ApparentTemp *float64 `json:",omitempty"`
+	WindSpeed    *float64 `json:",omitempty"`
+	HeatIndex    *float64 `json:",omitempty"`
+	WBGT         *float64 `json:",omitempty"`
+	// daily precipitation sum and highest probability averaged over the
+	// horizon, and the most frequent daily weather code
+	AvgPrecipitationSum         *float64 `json:",omitempty"`
+	AvgPrecipitationProbability *float64 `json:",omitempty"`
+	WeatherCode                 *int     `json:",omitempty"`
+	// AQI is the index of a typical day of the forecast horizon
+	AQI *aqi.Result `json:",omitempty"`
+	// Daily are the travel conditions of each day of the horizon, keyed by
+	// YYYY-MM-DD, without the UV index
+	Daily map[string]*TravelConditions `json:",omitempty"`
+	// Anomaly is filled from the climate normals when the cache is read
+	Anomaly *ClimateAnomaly `json:",omitempty"`
+	// DistanceKm is filled when the ranking is narrowed to a location
+	DistanceKm *float64 `json:",omitempty"`
+}
+
+// FeelsLike is the apparent temperature, falling back to the heat index and
+// then to the air temperature for entries missing them
+func (d DistrictCache) FeelsLike() float64 {
+	switch {
+	case d.ApparentTemp != nil:
+		return *d.ApparentTemp
+	case d.HeatIndex != nil:
+		return *d.HeatIndex
+	default:
+		return d.Temp
+	}
+}
+
+// MeanTemp is the mean temperature of the samples, whatever Statistic is
+func (d DistrictCache) MeanTemp() float64 {
+	if d.TempStats != nil {
+		return d.TempStats.Avg
+	}
+	return d.Temp
+}
+
+// MeanPM25 is the mean PM2.5 of the samples, whatever Statistic is
+func (d DistrictCache) MeanPM25() float64 {
+	if d.PM25Stats != nil {
+		return d.PM25Stats.Avg
+	}
+	return d.PM25
+}
+
+// Age is how long before now the entry was fetched, false when unknown
+func (d DistrictCache) Age(now time.Time) (time.Duration, bool) {
+	if d.UpdatedAt.IsZero() {
+		return 0, false
+	}
+	return now.Sub(d.UpdatedAt), true
 }
+
+// Conditions are the cached metrics as the travel conditions of a typical
+// day of the horizon
+func (d DistrictCache) Conditions() *TravelConditions {
+	return &TravelConditions{
+		Temp2PM:                  d.Temp,
+		PM25:                     d.PM25,
+		Humidity2PM:              d.Humidity,
+		HeatIndex2PM:             d.HeatIndex,
+		PrecipitationProbability: d.AvgPrecipitationProbability,
+		WindSpeed2PM:             d.WindSpeed,
+		PrecipitationSum:         d.AvgPrecipitationSum,
+		WeatherCode:              d.WeatherCode,
+		AQI:                      d.AQI,
+	}
+}
+
+// Rainy reports whether a typical day of the horizon is wet
+func (d DistrictCache) Rainy() bool {
+	return (d.AvgPrecipitationProbability != nil && *d.AvgPrecipitationProbability >= 60) ||
+		(d.AvgPrecipitationSum != nil && *d.AvgPrecipitationSum >= 10) ||
+		(d.WeatherCode != nil && IsRainCode(*d.WeatherCode))
+}
+
 type DistrictCriteria struct {
+	ID           *int64
 	DistrictName *string
 }
 type District struct {
-	ID         int64     `json:"id"`
-	DivisionID int       `json:"division_id"`
-	Name       string    `json:"name"`
-	BnName     string    `json:"bn_name"`
-	Lat        float64   `json:"lat"`
-	Long       float64   `json:"long"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         int64            `json:"id"`
+	DivisionID int              `json:"division_id"`
+	Name       string           `json:"name"`
+	BnName     string           `json:"bn_name"`
+	Lat        float64          `json:"lat"`
+	Long       float64          `json:"long"`
+	Boundary   geo.MultiPolygon `json:"-" gorm:"serializer:json"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// DailySun is the sun exposure of a local day. Sunrise and Sunset are nil
+// during polar day and night.
+type DailySun struct {
+	Date          string     `json:"date"`
+	UVIndexMax    *float64   `json:"uv_index_max"`
+	Sunrise       *time.Time `json:"sunrise"`
+	Sunset        *time.Time `json:"sunset"`
+	DaylightHours *float64   `json:"daylight_hours"`
+}
+
+// DistrictForecast is the daily forecast of a district over the forecast horizon
+type DistrictForecast struct {
+	District *District
+	Days     []*DailySun
 }
 
 type DistrictRepository interface {
 	List(ctx context.Context, ctr *DistrictCriteria) ([]*District, error)
 }
 
+type DistrictUsecase interface {
+	List(ctx context.Context) ([]*District, error)
+	Get(ctx context.Context, id int64) (*District, error)
+	Locate(ctx context.Context, lat, long float64) (*District, error)
+	History(ctx context.Context, ctr *ObservationCriteria) (*DistrictHistory, error)
+	Forecast(ctx context.Context, id int64) (*DistrictForecast, error)
+}
+
 var (
 	ErrDistrictNotFound = errors.New("district not found")
 )
@@ -0,0 +1,39 @@
+package domain
+
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// EnsembleStat is the mean and standard deviation of a metric across the
+// models that forecast it
+type EnsembleStat struct {
+	Mean    float64 `json:"mean"`
+	Spread  float64 `json:"spread"`
+	Members int     `json:"members"`
+}
+
+// EnsembleForecast summarizes the models forecasting a place on a date,
+// metrics fewer than two models have are nil
+type EnsembleForecast struct {
+	Models           []string      `json:"models"`
+	Temp2PM          *EnsembleStat `json:"temp_2pm,omitempty"`
+	PrecipitationSum *EnsembleStat `json:"precipitation_sum,omitempty"`
+}
+
+// ForecastConfidence tells how far a recommendation can be trusted. Score
+// (0-1) decreases with the lead time and the disagreement between models.
+type ForecastConfidence struct {
+	Level    string  `json:"level"`
+	Score    float64 `json:"score"`
+	LeadDays int     `json:"lead_days"`
+	// ScoredModel is the single model the recommendation is scored on, the
+	// ensemble only rates how far that forecast can be trusted
+	ScoredModel string `json:"scored_model"`
+	// Disagreement (0-1) is the largest model spread relative to its limit,
+	// nil when no ensemble was available
+	Disagreement *float64          `json:"disagreement,omitempty"`
+	Origin       *EnsembleForecast `json:"origin,omitempty"`
+	Destination  *EnsembleForecast `json:"destination,omitempty"`
+}
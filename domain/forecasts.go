@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ForecastSnapshot is the forecast of a district for TargetDate as issued at
+// IssuedAt, LeadDays before it. The observed values are filled once the
+// target date has passed and been verified.
+type ForecastSnapshot struct {
+	DistrictID      int64      `json:"district_id"`
+	IssuedAt        time.Time  `json:"issued_at"`
+	TargetDate      time.Time  `json:"target_date" gorm:"type:date"`
+	LeadDays        int        `json:"lead_days"`
+	Temp2PM         *float64   `json:"temp_2pm" gorm:"column:temp_2pm"`
+	PM25            *float64   `json:"pm25" gorm:"column:pm25"`
+	ObservedTemp2PM *float64   `json:"observed_temp_2pm" gorm:"column:observed_temp_2pm"`
+	ObservedPM25    *float64   `json:"observed_pm25" gorm:"column:observed_pm25"`
+	VerifiedAt      *time.Time `json:"verified_at"`
+}
+
+// ForecastTarget is a district day with snapshots awaiting verification
+type ForecastTarget struct {
+	DistrictID int64
+	TargetDate time.Time
+}
+
+// ForecastAccuracy is the mean absolute error and the bias (forecast minus
+// observed) of the verified forecasts of a district at a lead time
+type ForecastAccuracy struct {
+	DistrictID int64    `json:"district_id"`
+	District   string   `json:"district"`
+	LeadDays   int      `json:"lead_days"`
+	Samples    int      `json:"samples"`
+	TempMAE    *float64 `json:"temp_mae" gorm:"column:temp_mae"`
+	TempBias   *float64 `json:"temp_bias" gorm:"column:temp_bias"`
+	PM25MAE    *float64 `json:"pm25_mae" gorm:"column:pm25_mae"`
+	PM25Bias   *float64 `json:"pm25_bias" gorm:"column:pm25_bias"`
+}
+
+// ForecastAccuracyCriteria narrows the accuracy to a district and to target
+// dates in [From, To), zero times leave that end open
+type ForecastAccuracyCriteria struct {
+	DistrictID *int64
+	From       time.Time
+	To         time.Time
+}
+
+type ForecastSnapshotRepository interface {
+	// Upsert stores the snapshots, replacing those of the same district, issue time and target date
+	Upsert(ctx context.Context, snapshots []*ForecastSnapshot) error
+	// Pending lists the district days in [from, before) still awaiting verification
+	Pending(ctx context.Context, from, before time.Time) ([]ForecastTarget, error)
+	// Verify records the observed values on every snapshot of a district day
+	Verify(ctx context.Context, districtID int64, targetDate time.Time, temp2PM, pm25 *float64) error
+	Accuracy(ctx context.Context, ctr *ForecastAccuracyCriteria) ([]*ForecastAccuracy, error)
+}
+
+type ForecastUsecase interface {
+	Accuracy(ctx context.Context, ctr *ForecastAccuracyCriteria) ([]*ForecastAccuracy, error)
+}
+
+var (
+	ErrInvalidAccuracyRange = errors.New("from must be before to")
+)
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+)
+
+// ItineraryRequest asks for a day by day plan from Start. MustVisit districts
+// are always planned, Optional ones fill the days left over.
+type ItineraryRequest struct {
+	Start     Coordinate `json:"start"`
+	DateFrom  string     `json:"date_from"`
+	DateTo    string     `json:"date_to"`
+	MustVisit []string   `json:"must_visit"`
+	Optional  []string   `json:"optional"`
+}
+
+// ItineraryStop is one district of the plan and the day it is visited.
+// DistanceKm is measured from the previous stop, or the start for the first.
+type ItineraryStop struct {
+	Order          int               `json:"order"`
+	Date           string            `json:"date"`
+	District       string            `json:"district"`
+	MustVisit      bool              `json:"must_visit"`
+	DistanceKm     float64           `json:"distance_km"`
+	Score          float64           `json:"score"`
+	Recommendation string            `json:"recommendation"`
+	Conditions     *TravelConditions `json:"conditions"`
+	// Cached is set when the forecast was unavailable and the scheduler's
+	// cached conditions were used instead
+	Cached bool `json:"cached,omitempty"`
+}
+
+type ItineraryResponse struct {
+	Start           Coordinate      `json:"start"`
+	DateFrom        string          `json:"date_from"`
+	DateTo          string          `json:"date_to"`
+	TotalDistanceKm float64         `json:"total_distance_km"`
+	Stops           []ItineraryStop `json:"stops"`
+	// Skipped are the optional districts that did not fit in the window
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+var (
+	ErrNoStops           = errors.New("at least one district to visit is required")
+	ErrTooManyStops      = errors.New("more must visit districts than days")
+	ErrTooManyCandidates = errors.New("too many districts to visit")
+)
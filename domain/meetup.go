@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+)
+
+const (
+	MeetupObjectiveTotal = "total"
+	MeetupObjectiveMax   = "max"
+)
+
+type Coordinate struct {
+	Lat  float64 `json:"lat"`
+	Long float64 `json:"long"`
+}
+
+// MeetupRequest asks for a district every origin can travel to. Objective
+// picks whether the total or the longest travel distance is minimized.
+type MeetupRequest struct {
+	Origins    []Coordinate `json:"origins"`
+	TravelDate string       `json:"travel_date"`
+	Objective  string       `json:"objective"`
+}
+
+// MeetupOrigin is how a meeting point compares for one of the origins
+type MeetupOrigin struct {
+	Origin         Coordinate `json:"origin"`
+	DistanceKm     float64    `json:"distance_km"`
+	TempDiff       float64    `json:"temp_diff"`
+	PM25Diff       float64    `json:"pm25_diff"`
+	Score          float64    `json:"score"`
+	Recommendation string     `json:"recommendation"`
+}
+
+// MeetupSuggestion is a meeting point scored on the comfort it brings every
+// origin and the distance they travel
+type MeetupSuggestion struct {
+	Rank            int               `json:"rank"`
+	District        string            `json:"district"`
+	Score           float64           `json:"score"`
+	ComfortScore    float64           `json:"comfort_score"`
+	DistanceScore   float64           `json:"distance_score"`
+	TotalDistanceKm float64           `json:"total_distance_km"`
+	MaxDistanceKm   float64           `json:"max_distance_km"`
+	Conditions      *TravelConditions `json:"conditions"`
+	Origins         []MeetupOrigin    `json:"origins"`
+}
+
+type MeetupResponse struct {
+	TravelDate  string             `json:"travel_date"`
+	Objective   string             `json:"objective"`
+	Suggestions []MeetupSuggestion `json:"suggestions"`
+}
+
+var (
+	ErrNoOrigins        = errors.New("at least one origin is required")
+	ErrTooManyOrigins   = errors.New("too many origins")
+	ErrInvalidObjective = errors.New("objective must be total or max")
+)
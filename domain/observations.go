@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+)
+
+// DistrictObservation is one hourly (or aggregated daily) weather and air quality sample
+type DistrictObservation struct {
+	DistrictID       int64     `json:"district_id"`
+	ObservedAt       time.Time `json:"observed_at"`
+	Temperature      *float64  `json:"temperature_2m" gorm:"column:temperature_2m"`
+	RelativeHumidity *float64  `json:"relative_humidity_2m" gorm:"column:relative_humidity_2m"`
+	Precipitation    *float64  `json:"precipitation"`
+	WindSpeed        *float64  `json:"wind_speed_10m" gorm:"column:wind_speed_10m"`
+	PM25             *float64  `json:"pm2_5" gorm:"column:pm2_5"`
+	PM10             *float64  `json:"pm10" gorm:"column:pm10"`
+}
+
+type ObservationCriteria struct {
+	DistrictID  int64
+	From        time.Time
+	To          time.Time
+	Granularity string
+	// TimeZone is the IANA zone daily buckets are cut in
+	TimeZone string
+}
+
+// DistrictHistory is the district and its observations over a history query
+type DistrictHistory struct {
+	District     *District
+	Observations []*DistrictObservation
+}
+
+type ObservationRepository interface {
+	Upsert(ctx context.Context, obs []*DistrictObservation) error
+	History(ctx context.Context, ctr *ObservationCriteria) ([]*DistrictObservation, error)
+}
+
+var (
+	ErrInvalidGranularity = errors.New("granularity must be hour or day")
+	ErrInvalidTimeRange   = errors.New("invalid time range")
+)
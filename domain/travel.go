@@ -1,23 +1,223 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+	"travel_advisor/pkg/aqi"
+)
 
 type TravelRecommendationRequest struct {
 	CurrentLat          float64 `json:"current_lat"`
 	CurrentLong         float64 `json:"current_long"`
 	DestinationDistrict string  `json:"destination_district"`
 	TravelDate          string  `json:"travel_date"`
+	RainTolerance       string  `json:"rain_tolerance"`
 }
 
+const (
+	// RainToleranceLow doubles the weight of rain, RainToleranceHigh ignores
+	// it and RainToleranceMedium, the default, keeps the configured weight
+	RainToleranceLow    = "low"
+	RainToleranceMedium = "medium"
+	RainToleranceHigh   = "high"
+)
+
 type TravelRecommendationResponse struct {
 	Destination    string  `json:"destination"`
 	Recommendation string  `json:"recommendation"`
 	Reason         string  `json:"reason"`
 	TempDiff       float64 `json:"temp_diff"`
 	PM25Diff       float64 `json:"pm25_diff"`
+	Score          float64 `json:"score"`
+	// Reasons are the structured items Reason is rendered from
+	Reasons []ReasonItem `json:"reasons"`
+	// Factors is the per factor breakdown of Score
+	Factors []FactorScore `json:"factors"`
+	// DestinationAnomaly compares the destination to its normal for the travel date
+	DestinationAnomaly *ClimateAnomaly `json:"destination_anomaly,omitempty"`
+	// OriginAQI and DestinationAQI are the air quality indices of the travel date
+	OriginAQI      *aqi.Result `json:"origin_aqi,omitempty"`
+	DestinationAQI *aqi.Result `json:"destination_aqi,omitempty"`
+	// Alternatives are nearby districts suggested when the destination is not recommended
+	Alternatives []Alternative `json:"alternatives,omitempty"`
+	// Confidence is how reliable the forecast behind the recommendation is
+	Confidence *ForecastConfidence `json:"confidence,omitempty"`
+	// Stale is set when live forecasts were unavailable and the cached
+	// district averages were compared instead. DataAgeSeconds is the age
+	// of the older of the two entries, when known.
+	Stale          bool   `json:"stale"`
+	DataAgeSeconds *int64 `json:"data_age_seconds,omitempty"`
+}
+
+// Alternative is a cached district cooler and cleaner than the origin
+type Alternative struct {
+	District                  string  `json:"district"`
+	DistanceFromDestinationKm float64 `json:"distance_from_destination_km"`
+	DistanceFromOriginKm      float64 `json:"distance_from_origin_km"`
+	AvgTemp2PM                float64 `json:"avg_temp_2pm"`
+	AvgPM25                   float64 `json:"avg_pm25"`
+}
+
+type TravelCompareRequest struct {
+	CurrentLat           float64  `json:"current_lat"`
+	CurrentLong          float64  `json:"current_long"`
+	DestinationDistricts []string `json:"destination_districts"`
+	TravelDate           string   `json:"travel_date"`
+	RainTolerance        string   `json:"rain_tolerance"`
+}
+
+// ComparedDestination is a destination recommendation with its rank among the candidates
+type ComparedDestination struct {
+	Rank int `json:"rank"`
+	*TravelRecommendationResponse
+}
+
+type TravelCompareResponse struct {
+	TravelDate   string                `json:"travel_date"`
+	Destinations []ComparedDestination `json:"destinations"`
+}
+
+type BestDateRequest struct {
+	CurrentLat          float64 `json:"current_lat"`
+	CurrentLong         float64 `json:"current_long"`
+	DestinationDistrict string  `json:"destination_district"`
+	DateFrom            string  `json:"date_from"`
+	DateTo              string  `json:"date_to"`
+	RainTolerance       string  `json:"rain_tolerance"`
+}
+
+// RankedTravelDate is the recommendation for one day of the window with the metrics it was scored on
+type RankedTravelDate struct {
+	Rank               int               `json:"rank"`
+	Date               string            `json:"date"`
+	OriginMetrics      *TravelConditions `json:"origin_metrics"`
+	DestinationMetrics *TravelConditions `json:"destination_metrics"`
+	*TravelRecommendationResponse
+}
+
+type BestDateResponse struct {
+	Destination string             `json:"destination"`
+	Dates       []RankedTravelDate `json:"dates"`
+}
+
+// HourlyCondition is the weather and air quality of one local hour. Score is
+// the combined heat and pollution discomfort, lower is better.
+type HourlyCondition struct {
+	Time             time.Time `json:"time"`
+	Temperature      *float64  `json:"temperature"`
+	RelativeHumidity *float64  `json:"relative_humidity"`
+	HeatIndex        *float64  `json:"heat_index"`
+	PM25             *float64  `json:"pm2_5"`
+	Score            *float64  `json:"score"`
+}
+
+// TimeWindow is a run of contiguous hours, End is exclusive
+type TimeWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Score float64   `json:"score"`
+}
+
+type HourlyForecastResponse struct {
+	DistrictID int64              `json:"district_id"`
+	District   string             `json:"district"`
+	Date       string             `json:"date"`
+	Hours      []*HourlyCondition `json:"hours"`
+	// DepartureWindow is the most comfortable window of the day and
+	// ArrivalWindow the most comfortable one starting after it
+	DepartureWindow *TimeWindow `json:"departure_window,omitempty"`
+	ArrivalWindow   *TimeWindow `json:"arrival_window,omitempty"`
+}
+
+const (
+	SortByCoolness  = "coolness"
+	SortByDistance  = "distance"
+	SortByFeelsLike = "feels_like"
+)
+
+// CoolestCriteria narrows the coolest districts ranking to the districts
+// within RadiusKm of a location. Without a location the ranking is nationwide.
+type CoolestCriteria struct {
+	Lat      *float64
+	Long     *float64
+	RadiusKm float64
+	SortBy   string
+	// RainTolerance low leaves rainy districts out, high ignores rain and
+	// medium, the default, ranks them after the dry ones
+	RainTolerance string
 }
 
 type TravelUsecase interface {
-	CoolestDistricts(ctx context.Context) ([]DistrictCache, error)
+	CoolestDistricts(ctx context.Context, ctr *CoolestCriteria) ([]DistrictCache, error)
 	RecommendTravel(ctx context.Context, req TravelRecommendationRequest) (*TravelRecommendationResponse, error)
+	CompareDestinations(ctx context.Context, req TravelCompareRequest) (*TravelCompareResponse, error)
+	BestTravelDate(ctx context.Context, req BestDateRequest) (*BestDateResponse, error)
+	HourlyForecast(ctx context.Context, districtID int64, date string) (*HourlyForecastResponse, error)
+	Meetup(ctx context.Context, req MeetupRequest) (*MeetupResponse, error)
+	Itinerary(ctx context.Context, req ItineraryRequest) (*ItineraryResponse, error)
+}
+
+var (
+	ErrNoDestinations       = errors.New("at least one destination district is required")
+	ErrTooManyDestinations  = errors.New("too many destination districts")
+	ErrDestinationNotFound  = errors.New("destination district not found")
+	ErrInvalidDateRange     = errors.New("invalid travel date range")
+	ErrInvalidTravelDate    = errors.New("invalid travel date")
+	ErrInvalidLocation      = errors.New("lat, long and a positive radius_km are required together")
+	ErrInvalidSort          = errors.New("sort must be coolness, feels_like or distance")
+	ErrInvalidRainTolerance = errors.New("rain_tolerance must be low, medium or high")
+)
+
+const (
+	VerdictRecommended    = "Recommended"
+	VerdictAcceptable     = "Acceptable"
+	VerdictNotRecommended = "Not Recommended"
+)
+
+const (
+	FactorTemperature   = "temperature"
+	FactorPM25          = "pm25"
+	FactorHeatIndex     = "heat_index"
+	FactorPrecipitation = "precipitation"
+	FactorWind          = "wind"
+	FactorRain          = "rain"
+	FactorUV            = "uv"
+)
+
+// FactorScore is the contribution of a single factor to the travel score
+type FactorScore struct {
+	Factor      string   `json:"factor"`
+	Weight      float64  `json:"weight"`
+	Score       float64  `json:"score"`
+	Origin      *float64 `json:"origin,omitempty"`
+	Destination float64  `json:"destination"`
+}
+
+// TravelScore is the 0-100 rating of a destination and its verdict tier
+type TravelScore struct {
+	Score   float64       `json:"score"`
+	Verdict string        `json:"verdict"`
+	Factors []FactorScore `json:"factors"`
+}
+
+const (
+	DirectionBetter = "better"
+	DirectionWorse  = "worse"
+	DirectionSame   = "same"
+)
+
+const (
+	SeverityLow      = "low"
+	SeverityModerate = "moderate"
+	SeverityHigh     = "high"
+)
+
+// ReasonItem explains how one factor compares between destination and origin
+type ReasonItem struct {
+	Factor    string  `json:"factor"`
+	Direction string  `json:"direction"`
+	Magnitude float64 `json:"magnitude"`
+	Unit      string  `json:"unit"`
+	Severity  string  `json:"severity"`
 }
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ForecastHandler struct {
+	ForecastUsecase domain.ForecastUsecase
+}
+
+func NewForecastHandler(r *chi.Mux, f domain.ForecastUsecase) {
+	handler := &ForecastHandler{
+		ForecastUsecase: f,
+	}
+	r.Route("/v1/admin", func(r chi.Router) {
+		r.Use(helpers.JWTAuthMiddleware)
+		r.Get("/forecast-accuracy", handler.Accuracy)
+	})
+}
+
+func (h *ForecastHandler) Accuracy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctr, err := parseAccuracyCriteria(r)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	accuracy, err := h.ForecastUsecase.Accuracy(ctx, ctr)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, domain.ErrInvalidAccuracyRange):
+			status = http.StatusBadRequest
+		case errors.Is(err, domain.ErrDistrictNotFound):
+			status = http.StatusNotFound
+		}
+		resp := &helpers.Response{
+			Status:  status,
+			Message: "forecast accuracy fetch failed",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   accuracy,
+	}
+	resp.Render(w)
+}
+
+// parseAccuracyCriteria reads the optional district_id and the from and to
+// target dates (YYYY-MM-DD, to inclusive)
+func parseAccuracyCriteria(r *http.Request) (*domain.ForecastAccuracyCriteria, error) {
+	q := r.URL.Query()
+	ctr := &domain.ForecastAccuracyCriteria{}
+
+	if v := q.Get("district_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid district_id: %v", err)
+		}
+		ctr.DistrictID = &id
+	}
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return nil, errors.New("from must be YYYY-MM-DD")
+		}
+		ctr.From = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return nil, errors.New("to must be YYYY-MM-DD")
+		}
+		ctr.To = t.AddDate(0, 0, 1)
+	}
+	return ctr, nil
+}
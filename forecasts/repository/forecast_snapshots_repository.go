@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/conn"
+
+	"gorm.io/gorm/clause"
+)
+
+const snapshotBatchSize = 500
+
+type ForecastSnapshotPostgreSQL struct {
+	db *conn.DB
+}
+
+func NewForecastSnapshotPostgreSQL(db *conn.DB) domain.ForecastSnapshotRepository {
+	return &ForecastSnapshotPostgreSQL{
+		db: db,
+	}
+}
+
+// Upsert inserts the snapshots, overwriting the forecast values of any already
+// stored for the same district, issue time and target date
+func (r *ForecastSnapshotPostgreSQL) Upsert(ctx context.Context, snapshots []*domain.ForecastSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	err := r.db.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "district_id"}, {Name: "issued_at"}, {Name: "target_date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"lead_days", "temp_2pm", "pm25"}),
+		}).
+		CreateInBatches(snapshots, snapshotBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("repository:postgreSQL: failed to upsert forecast snapshots: %v", err)
+	}
+	return nil
+}
+
+func (r *ForecastSnapshotPostgreSQL) Pending(ctx context.Context, from, before time.Time) ([]domain.ForecastTarget, error) {
+	var targets []domain.ForecastTarget
+	err := r.db.DB.WithContext(ctx).
+		Model(&domain.ForecastSnapshot{}).
+		Distinct("district_id", "target_date").
+		Where("verified_at IS NULL AND target_date >= ? AND target_date < ?",
+			from.Format(time.DateOnly), before.Format(time.DateOnly)).
+		Order("district_id, target_date").
+		Scan(&targets).Error
+	if err != nil {
+		return nil, fmt.Errorf("repository:postgreSQL: failed to fetch pending forecast snapshots: %v", err)
+	}
+	return targets, nil
+}
+
+func (r *ForecastSnapshotPostgreSQL) Verify(ctx context.Context, districtID int64, targetDate time.Time, temp2PM, pm25 *float64) error {
+	err := r.db.DB.WithContext(ctx).
+		Model(&domain.ForecastSnapshot{}).
+		Where("district_id = ? AND target_date = ?", districtID, targetDate.Format(time.DateOnly)).
+		Updates(map[string]interface{}{
+			"observed_temp_2pm": temp2PM,
+			"observed_pm25":     pm25,
+			"verified_at":       time.Now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("repository:postgreSQL: failed to verify forecast snapshots: %v", err)
+	}
+	return nil
+}
+
+// Accuracy aggregates the verified snapshots per district and lead time
+func (r *ForecastSnapshotPostgreSQL) Accuracy(ctx context.Context, ctr *domain.ForecastAccuracyCriteria) ([]*domain.ForecastAccuracy, error) {
+	list := make([]*domain.ForecastAccuracy, 0)
+
+	q := r.db.DB.WithContext(ctx).
+		Table("forecast_snapshots s").
+		Select(`s.district_id, d.name AS district, s.lead_days, COUNT(*) AS samples,
+			AVG(ABS(s.temp_2pm - s.observed_temp_2pm)) AS temp_mae,
+			AVG(s.temp_2pm - s.observed_temp_2pm) AS temp_bias,
+			AVG(ABS(s.pm25 - s.observed_pm25)) AS pm25_mae,
+			AVG(s.pm25 - s.observed_pm25) AS pm25_bias`).
+		Joins("JOIN districts d ON d.id = s.district_id").
+		Where("s.verified_at IS NOT NULL")
+	if ctr.DistrictID != nil {
+		q = q.Where("s.district_id = ?", *ctr.DistrictID)
+	}
+	if !ctr.From.IsZero() {
+		q = q.Where("s.target_date >= ?", ctr.From.Format(time.DateOnly))
+	}
+	if !ctr.To.IsZero() {
+		q = q.Where("s.target_date < ?", ctr.To.Format(time.DateOnly))
+	}
+
+	err := q.Group("s.district_id, d.name, s.lead_days").
+		Order("d.name, s.lead_days").
+		Scan(&list).Error
+	if err != nil {
+		return nil, fmt.Errorf("repository:postgreSQL: failed to compute forecast accuracy: %v", err)
+	}
+	return list, nil
+}
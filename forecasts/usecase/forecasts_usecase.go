@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"travel_advisor/domain"
+)
+
+type ForecastUsecase struct {
+	DistrictsRepository domain.DistrictRepository
+	SnapshotsRepository domain.ForecastSnapshotRepository
+}
+
+func NewForecastUsecase(d domain.DistrictRepository, s domain.ForecastSnapshotRepository) domain.ForecastUsecase {
+	return &ForecastUsecase{
+		DistrictsRepository: d,
+		SnapshotsRepository: s,
+	}
+}
+
+// Accuracy returns the error of the verified forecasts per district and lead time
+func (u *ForecastUsecase) Accuracy(ctx context.Context, ctr *domain.ForecastAccuracyCriteria) ([]*domain.ForecastAccuracy, error) {
+	if !ctr.From.IsZero() && !ctr.To.IsZero() && !ctr.From.Before(ctr.To) {
+		return nil, domain.ErrInvalidAccuracyRange
+	}
+	if ctr.DistrictID != nil {
+		districts, err := u.DistrictsRepository.List(ctx, &domain.DistrictCriteria{ID: ctr.DistrictID})
+		if err != nil {
+			return nil, err
+		}
+		if len(districts) == 0 {
+			return nil, domain.ErrDistrictNotFound
+		}
+	}
+	return u.SnapshotsRepository.Accuracy(ctx, ctr)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+	"travel_advisor/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDistrictRepository struct {
+	mock.Mock
+}
+
+func (m *MockDistrictRepository) List(ctx context.Context, ctr *domain.DistrictCriteria) ([]*domain.District, error) {
+	args := m.Called(ctx, ctr)
+	return args.Get(0).([]*domain.District), args.Error(1)
+}
+
+type MockForecastSnapshotRepository struct {
+	mock.Mock
+}
+
+func (m *MockForecastSnapshotRepository) Upsert(ctx context.Context, snapshots []*domain.ForecastSnapshot) error {
+	args := m.Called(ctx, snapshots)
+	return args.Error(0)
+}
+
+func (m *MockForecastSnapshotRepository) Pending(ctx context.Context, from, before time.Time) ([]domain.ForecastTarget, error) {
+	args := m.Called(ctx, from, before)
+	return args.Get(0).([]domain.ForecastTarget), args.Error(1)
+}
+
+func (m *MockForecastSnapshotRepository) Verify(ctx context.Context, districtID int64, targetDate time.Time, temp2PM, pm25 *float64) error {
+	args := m.Called(ctx, districtID, targetDate, temp2PM, pm25)
+	return args.Error(0)
+}
+
+func (m *MockForecastSnapshotRepository) Accuracy(ctx context.Context, ctr *domain.ForecastAccuracyCriteria) ([]*domain.ForecastAccuracy, error) {
+	args := m.Called(ctx, ctr)
+	return args.Get(0).([]*domain.ForecastAccuracy), args.Error(1)
+}
+
+func TestForecastUsecase_Accuracy(t *testing.T) {
+	id := int64(47)
+	mae := 1.2
+	accuracy := []*domain.ForecastAccuracy{{DistrictID: id, District: "Dhaka", LeadDays: 1, Samples: 24, TempMAE: &mae}}
+
+	tests := []struct {
+		name          string
+		ctr           *domain.ForecastAccuracyCriteria
+		setupMocks    func(*MockDistrictRepository, *MockForecastSnapshotRepository)
+		expected      []*domain.ForecastAccuracy
+		expectedError error
+	}{
+		{
+			name: "Every district",
+			ctr:  &domain.ForecastAccuracyCriteria{},
+			setupMocks: func(d *MockDistrictRepository, s *MockForecastSnapshotRepository) {
+				s.On("Accuracy", mock.Anything, &domain.ForecastAccuracyCriteria{}).Return(accuracy, nil)
+			},
+			expected: accuracy,
+		},
+		{
+			name: "Known district",
+			ctr:  &domain.ForecastAccuracyCriteria{DistrictID: &id},
+			setupMocks: func(d *MockDistrictRepository, s *MockForecastSnapshotRepository) {
+				d.On("List", mock.Anything, &domain.DistrictCriteria{ID: &id}).Return([]*domain.District{{ID: id}}, nil)
+				s.On("Accuracy", mock.Anything, mock.Anything).Return(accuracy, nil)
+			},
+			expected: accuracy,
+		},
+		{
+			name: "Unknown district",
+			ctr:  &domain.ForecastAccuracyCriteria{DistrictID: &id},
+			setupMocks: func(d *MockDistrictRepository, s *MockForecastSnapshotRepository) {
+				d.On("List", mock.Anything, mock.Anything).Return([]*domain.District{}, nil)
+			},
+			expectedError: domain.ErrDistrictNotFound,
+		},
+		{
+			name: "Reversed range",
+			ctr: &domain.ForecastAccuracyCriteria{
+				From: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			},
+			setupMocks:    func(d *MockDistrictRepository, s *MockForecastSnapshotRepository) {},
+			expectedError: domain.ErrInvalidAccuracyRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			districts := new(MockDistrictRepository)
+			snapshots := new(MockForecastSnapshotRepository)
+			tt.setupMocks(districts, snapshots)
+
+			result, err := NewForecastUsecase(districts, snapshots).Accuracy(context.Background(), tt.ctr)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+			districts.AssertExpectations(t)
+			snapshots.AssertExpectations(t)
+		})
+	}
+}
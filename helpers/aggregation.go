@@ -0,0 +1,93 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/stats"
+)
+
+// MaxWeatherForecastDays is the longest horizon the weather forecast API serves
+const MaxWeatherForecastDays = 16
+
+var ErrInvalidAggregation = errors.New("invalid aggregation")
+
+// Aggregation selects the samples the district metrics are computed from and
+// the statistic reducing them
+type Aggregation struct {
+	HorizonDays int
+	// HourStart and HourEnd are inclusive local hours
+	HourStart int
+	HourEnd   int
+	Statistic stats.Statistic
+}
+
+// DefaultAggregation is the mean at 2PM over the 7 day forecast
+var DefaultAggregation = Aggregation{
+	HorizonDays: ForecastHorizonDays,
+	HourStart:   14,
+	HourEnd:     14,
+	Statistic:   stats.Mean,
+}
+
+// NewAggregation validates the configured aggregation. An unset horizon falls
+// back to the default, as does an unset hour window: midnight alone is never
+// a meaningful window.
+func NewAggregation(cfg config.AggregationCfg) (Aggregation, error) {
+	st, err := stats.ParseStatistic(cfg.Statistic)
+	if err != nil {
+		return Aggregation{}, fmt.Errorf("%w: %v", ErrInvalidAggregation, err)
+	}
+	agg := Aggregation{
+		HorizonDays: cfg.HorizonDays,
+		HourStart:   cfg.HourStart,
+		HourEnd:     cfg.HourEnd,
+		Statistic:   st,
+	}
+	if agg.HorizonDays == 0 {
+		agg.HorizonDays = DefaultAggregation.HorizonDays
+	}
+	if agg.HourStart == 0 && agg.HourEnd == 0 {
+		agg.HourStart, agg.HourEnd = DefaultAggregation.HourStart, DefaultAggregation.HourEnd
+	}
+
+	switch {
+	case agg.HorizonDays < 1 || agg.HorizonDays > MaxWeatherForecastDays:
+		return Aggregation{}, fmt.Errorf("%w: horizon_days must be between 1 and %d", ErrInvalidAggregation, MaxWeatherForecastDays)
+	case agg.HourStart < 0 || agg.HourEnd > 23 || agg.HourStart > agg.HourEnd:
+		return Aggregation{}, fmt.Errorf("%w: hours must satisfy 0 <= hour_start <= hour_end <= 23", ErrInvalidAggregation)
+	}
+	return agg, nil
+}
+
+// Includes reports whether a sample taken at t falls in the hour window
+func (a Aggregation) Includes(t time.Time) bool {
+	return t.Hour() >= a.HourStart && t.Hour() <= a.HourEnd
+}
+
+// summarize reduces the present values of a series, false when all are missing
+func summarize(vals []*float64) (stats.Summary, bool) {
+	present := make([]float64, 0, len(vals))
+	for _, v := range vals {
+		if v != nil {
+			present = append(present, *v)
+		}
+	}
+	return stats.Summarize(present)
+}
+
+// aggregate is the statistic of the present values, nil when all are missing
+func aggregate(vals []*float64, st stats.Statistic) *float64 {
+	s, ok := summarize(vals)
+	if !ok {
+		return nil
+	}
+	v := s.Value(st)
+	return &v
+}
+
+func metricStats(s stats.Summary) *domain.MetricStats {
+	return &domain.MetricStats{Avg: s.Mean, Min: s.Min, Max: s.Max, P90: s.P90}
+}
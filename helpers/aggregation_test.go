@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/stats"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAggregation(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.AggregationCfg
+		expected Aggregation
+		wantErr  bool
+	}{
+		{name: "Unset falls back to the defaults", expected: DefaultAggregation},
+		{
+			name:     "Afternoon p90 over 3 days",
+			cfg:      config.AggregationCfg{HorizonDays: 3, HourStart: 12, HourEnd: 16, Statistic: "p90"},
+			expected: Aggregation{HorizonDays: 3, HourStart: 12, HourEnd: 16, Statistic: stats.P90},
+		},
+		{name: "Unknown statistic", cfg: config.AggregationCfg{Statistic: "mode"}, wantErr: true},
+		{name: "Horizon beyond the forecast", cfg: config.AggregationCfg{HorizonDays: 17}, wantErr: true},
+		{name: "Reversed hours", cfg: config.AggregationCfg{HourStart: 16, HourEnd: 12}, wantErr: true},
+		{name: "Hour past the day", cfg: config.AggregationCfg{HourStart: 20, HourEnd: 24}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agg, err := NewAggregation(tt.cfg)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAggregation)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, agg)
+		})
+	}
+}
+
+func TestSummarizeWeather_Aggregation(t *testing.T) {
+	weather, err := decodeHourly(strings.NewReader(`{
+		"utc_offset_seconds": 21600,
+		"hourly": {
+			"time": ["2024-01-15T11:00", "2024-01-15T12:00", "2024-01-15T14:00", "2024-01-15T16:00", "2024-01-15T17:00"],
+			"temperature_2m": [40, 28, 30, 26, 10],
+			"relative_humidity_2m": [null, null, null, null, null],
+			"apparent_temperature": [null, null, null, null, null],
+			"wind_speed_10m": [1, 2, 3, 4, 5],
+			"precipitation": [0, 0, 0, 0, 0],
+			"weather_code": [0, 0, 0, 0, 0]
+		}
+	}`), weatherSummaryVars)
+	assert.NoError(t, err)
+
+	s, err := SummarizeWeather(weather, Aggregation{HorizonDays: 1, HourStart: 12, HourEnd: 16, Statistic: stats.Max})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, s.Temp, "the max of the 12-16h samples")
+	assert.InDelta(t, 28, s.TempStats.Avg, 1e-9)
+	assert.Equal(t, 26.0, s.TempStats.Min)
+	assert.Equal(t, 30.0, s.TempStats.Max)
+	assert.InDelta(t, 29.6, s.TempStats.P90, 1e-9)
+	assert.Equal(t, 4.0, *s.WindSpeed)
+	assert.Nil(t, s.Humidity)
+}
@@ -0,0 +1,180 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/aqi"
+	"travel_advisor/pkg/config"
+)
+
+// AirQualityVars are the pollutants the AQI is computed from
+var AirQualityVars = []string{"pm2_5", "pm10", "ozone", "nitrogen_dioxide", "sulphur_dioxide", "carbon_monoxide"}
+
+// AirQuality summarizes an air quality series, PM25 is reduced with the
+// statistic of the aggregation
+type AirQuality struct {
+	PM25      float64
+	PM25Stats *domain.MetricStats
+	AQI       *aqi.Result
+}
+
+// FetchAirQuality returns the PM2.5 and the AQI of a typical day, over date
+// or, without one, the aggregation horizon. The air quality forecast is
+// shorter than the weather one and longer horizons are cut to it.
+func FetchAirQuality(
+	ctx context.Context,
+	client *http.Client,
+	lat, long float64, date *string,
+	agg Aggregation,
+) (*AirQuality, error) {
+
+	air, err := fetchAirQualityHourly(ctx, client, lat, long, date, agg)
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeAirQuality(air, agg)
+}
+
+// fetchAirQualityHourly fetches the pollutants of date or, without one, of
+// the aggregation horizon
+func fetchAirQualityHourly(
+	ctx context.Context,
+	client *http.Client,
+	lat, long float64, date *string,
+	agg Aggregation,
+) (*HourlySeries, error) {
+
+	params := url.Values{}
+	if date != nil {
+		params.Set("start_date", *date)
+		params.Set("end_date", *date)
+	} else {
+		params.Set("forecast_days", strconv.Itoa(min(agg.HorizonDays, ForecastHorizonDays)))
+	}
+	return FetchHourly(ctx, client, AirQualityURL, lat, long, AirQualityVars, params)
+}
+
+// SummarizeAirQuality reduces PM2.5 over every hour and computes the AQI
+// from the mean of the daily concentrations
+func SummarizeAirQuality(air *HourlySeries, agg Aggregation) (*AirQuality, error) {
+	pm25, ok := summarize(air.Values["pm2_5"])
+	if !ok {
+		return nil, errors.New("no PM2.5 data")
+	}
+
+	days := DailyConcentrations(air)
+	list := make([]aqi.Concentrations, 0, len(days))
+	for _, c := range days {
+		list = append(list, c)
+	}
+
+	return &AirQuality{
+		PM25:      pm25.Value(agg.Statistic),
+		PM25Stats: metricStats(pm25),
+		AQI:       CalculateAQI(meanConcentrations(list)),
+	}, nil
+}
+
+// DailyConcentrations reduces the series of each local day to the AQI
+// averaging periods: the 24 hour mean of PM2.5 and PM10, the highest 8 hour
+// mean of O3 and CO and the highest hourly NO2 and SO2
+func DailyConcentrations(air *HourlySeries) map[string]aqi.Concentrations {
+	byDay := make(map[string][]int)
+	for i, t := range air.Time {
+		day := t.Format(time.DateOnly)
+		byDay[day] = append(byDay[day], i)
+	}
+
+	days := make(map[string]aqi.Concentrations, len(byDay))
+	for day, idx := range byDay {
+		values := func(variable string) []*float64 {
+			vals := make([]*float64, len(idx))
+			for j, i := range idx {
+				vals[j] = air.Value(variable, i)
+			}
+			return vals
+		}
+		days[day] = aqi.Concentrations{
+			PM25: optional(seriesMean(values("pm2_5"))),
+			PM10: optional(seriesMean(values("pm10"))),
+			O3:   optional(maxRollingMean(values("ozone"), 8)),
+			NO2:  optional(seriesMax(values("nitrogen_dioxide"))),
+			SO2:  optional(seriesMax(values("sulphur_dioxide"))),
+			CO:   optional(maxRollingMean(values("carbon_monoxide"), 8)),
+		}
+	}
+	return days
+}
+
+// CalculateAQI computes the index in the configured standard, US EPA when
+// unset or unknown. It is nil when no pollutant has data.
+func CalculateAQI(c aqi.Concentrations) *aqi.Result {
+	std, err := aqi.ParseStandard(config.AirQuality().AQIStandard)
+	if err != nil {
+		std = aqi.USEPA
+	}
+	res, err := aqi.Calculate(std, c)
+	if err != nil {
+		return nil
+	}
+	return res
+}
+
+// maxRollingMean is the highest mean of window consecutive hours, counting
+// windows with at least three quarters of their hours present. A day shorter
+// than window falls back to the mean of what it has.
+func maxRollingMean(vals []*float64, window int) (float64, bool) {
+	if len(vals) < window {
+		return seriesMean(vals)
+	}
+
+	var means []*float64
+	for i := 0; i+window <= len(vals); i++ {
+		var (
+			sum   float64
+			count int
+		)
+		for _, v := range vals[i : i+window] {
+			if v != nil {
+				sum += *v
+				count++
+			}
+		}
+		if count*4 >= window*3 {
+			m := sum / float64(count)
+			means = append(means, &m)
+		}
+	}
+	return seriesMax(means)
+}
+
+// meanConcentrations averages each pollutant over the days that have it
+func meanConcentrations(days []aqi.Concentrations) aqi.Concentrations {
+	field := func(get func(aqi.Concentrations) *float64) *float64 {
+		vals := make([]*float64, len(days))
+		for i, d := range days {
+			vals[i] = get(d)
+		}
+		return optional(seriesMean(vals))
+	}
+	return aqi.Concentrations{
+		PM25: field(func(c aqi.Concentrations) *float64 { return c.PM25 }),
+		PM10: field(func(c aqi.Concentrations) *float64 { return c.PM10 }),
+		O3:   field(func(c aqi.Concentrations) *float64 { return c.O3 }),
+		NO2:  field(func(c aqi.Concentrations) *float64 { return c.NO2 }),
+		SO2:  field(func(c aqi.Concentrations) *float64 { return c.SO2 }),
+		CO:   field(func(c aqi.Concentrations) *float64 { return c.CO }),
+	}
+}
+
+func optional(v float64, ok bool) *float64 {
+	if !ok {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/aqi"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyConcentrations(t *testing.T) {
+	air, err := decodeHourly(strings.NewReader(`{
+		"utc_offset_seconds": 21600,
+		"hourly": {
+			"time": ["2024-01-15T00:00", "2024-01-15T01:00", "2024-01-15T02:00", "2024-01-16T00:00"],
+			"pm2_5": [30, 40, 50, 20],
+			"pm10": [60, 70, null, 40],
+			"ozone": [80, 100, 90, null],
+			"nitrogen_dioxide": [20, 60, 40, 10],
+			"sulphur_dioxide": [null, null, null, 5],
+			"carbon_monoxide": [300, 600, 900, 200]
+		}
+	}`), AirQualityVars)
+	assert.NoError(t, err)
+
+	days := DailyConcentrations(air)
+
+	assert.Len(t, days, 2)
+	first := days["2024-01-15"]
+	assert.Equal(t, 40.0, *first.PM25)
+	assert.Equal(t, 65.0, *first.PM10)
+	assert.Equal(t, 90.0, *first.O3, "a day shorter than 8 hours uses its mean")
+	assert.Equal(t, 60.0, *first.NO2)
+	assert.Nil(t, first.SO2)
+	assert.Nil(t, days["2024-01-16"].O3)
+}
+
+func TestMaxRollingMean(t *testing.T) {
+	vals := make([]*float64, 0, 10)
+	for _, v := range []float64{10, 10, 10, 10, 10, 10, 10, 10, 50, 50} {
+		v := v
+		vals = append(vals, &v)
+	}
+	vals[1] = nil
+
+	got, ok := maxRollingMean(vals, 8)
+
+	assert.True(t, ok)
+	assert.InDelta(t, 20.0, got, 1e-9, "hours 2-9 hold 6x10 and 2x50")
+}
+
+func TestSummarizeAirQuality(t *testing.T) {
+	air, err := decodeHourly(strings.NewReader(`{
+		"utc_offset_seconds": 21600,
+		"hourly": {
+			"time": ["2024-01-15T00:00", "2024-01-16T00:00"],
+			"pm2_5": [30, 50],
+			"pm10": [null, null],
+			"ozone": [null, null],
+			"nitrogen_dioxide": [null, null],
+			"sulphur_dioxide": [null, null],
+			"carbon_monoxide": [null, null]
+		}
+	}`), AirQualityVars)
+	assert.NoError(t, err)
+
+	summary, err := SummarizeAirQuality(air, DefaultAggregation)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, summary.PM25)
+	assert.Equal(t, &domain.MetricStats{Avg: 40, Min: 30, Max: 50, P90: 48}, summary.PM25Stats)
+	if assert.NotNil(t, summary.AQI) {
+		assert.Equal(t, aqi.USEPA, summary.AQI.Standard)
+		assert.Equal(t, aqi.PM25, summary.AQI.Dominant)
+		assert.Equal(t, 112, summary.AQI.AQI)
+	}
+}
@@ -0,0 +1,238 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/log"
+)
+
+// FetchTravelConditions returns the 2PM weather and the mean PM2.5 of a
+// location on date (YYYY-MM-DD), with the UV index and daylight when sun is set
+func FetchTravelConditions(
+	ctx context.Context,
+	client *http.Client,
+	lat, long float64, date string, sun bool,
+) (*domain.TravelConditions, error) {
+
+	days, err := FetchDailyTravelConditions(ctx, client, lat, long, date, date, sun)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := days[date]
+	if !ok {
+		return nil, fmt.Errorf("no weather or PM2.5 data for %s", date)
+	}
+	return c, nil
+}
+
+// FetchDailyTravelConditions returns the travel conditions of every day from
+// the from to the to date (inclusive), keyed by YYYY-MM-DD. With sun set the
+// UV index and daylight are added from the daily forecast; they are optional,
+// so failing to fetch them leaves them nil.
+func FetchDailyTravelConditions(
+	ctx context.Context,
+	client *http.Client,
+	lat, long float64, from, to string, sun bool,
+) (map[string]*domain.TravelConditions, error) {
+
+	params := url.Values{}
+	params.Set("start_date", from)
+	params.Set("end_date", to)
+
+	endpoint := WeatherEndpointFor(to)
+	variables := []string{"temperature_2m", "relative_humidity_2m", "wind_speed_10m", "precipitation", "weather_code"}
+	if endpoint == WeatherForecastURL {
+		// the archive has no probabilistic variables
+		variables = append(variables, "precipitation_probability")
+	}
+
+	weather, err := FetchHourly(ctx, client, endpoint, lat, long, variables, params)
+	if err != nil {
+		return nil, err
+	}
+	air, err := FetchHourly(ctx, client, AirQualityURL, lat, long, AirQualityVars, params)
+	if err != nil {
+		return nil, err
+	}
+
+	days := BuildDailyTravelConditions(weather, air)
+	if sun && endpoint == WeatherForecastURL {
+		daily, err := FetchDailySun(ctx, client, lat, long, from, to)
+		if err != nil {
+			log.Warn("daily sun fetch failed ", err)
+		} else {
+			ApplyDailySun(days, daily)
+		}
+	}
+	return days, nil
+}
+
+// BuildDailyTravelConditions reduces hourly series to one set of travel
+// conditions per local day. Days missing the 2PM temperature or any PM2.5
+// sample are left out.
+func BuildDailyTravelConditions(weather, air *HourlySeries) map[string]*domain.TravelConditions {
+	pm25ByDay := make(map[string][]*float64)
+	for i, t := range air.Time {
+		day := t.Format(time.DateOnly)
+		pm25ByDay[day] = append(pm25ByDay[day], air.Value("pm2_5", i))
+	}
+	probsByDay := make(map[string][]*float64)
+	rainByDay := make(map[string][]*float64)
+	codesByDay := make(map[string][]*float64)
+	for i, t := range weather.Time {
+		day := t.Format(time.DateOnly)
+		probsByDay[day] = append(probsByDay[day], weather.Value("precipitation_probability", i))
+		rainByDay[day] = append(rainByDay[day], weather.Value("precipitation", i))
+		codesByDay[day] = append(codesByDay[day], weather.Value("weather_code", i))
+	}
+
+	concentrations := DailyConcentrations(air)
+
+	days := make(map[string]*domain.TravelConditions)
+	for i, t := range weather.Time {
+		if t.Hour() != 14 || weather.Value("temperature_2m", i) == nil {
+			continue
+		}
+		day := t.Format(time.DateOnly)
+		pm25, ok := seriesMean(pm25ByDay[day])
+		if !ok {
+			continue
+		}
+
+		c := &domain.TravelConditions{
+			Temp2PM:      *weather.Value("temperature_2m", i),
+			PM25:         pm25,
+			Humidity2PM:  weather.Value("relative_humidity_2m", i),
+			WindSpeed2PM: weather.Value("wind_speed_10m", i),
+			AQI:          CalculateAQI(concentrations[day]),
+		}
+		if c.Humidity2PM != nil {
+			hi := HeatIndex(c.Temp2PM, *c.Humidity2PM)
+			c.HeatIndex2PM = &hi
+		}
+		if p, ok := seriesMax(probsByDay[day]); ok {
+			c.PrecipitationProbability = &p
+		}
+		c.PrecipitationSum = optional(daySum(weather, day, rainByDay[day]))
+		if code, ok := seriesMax(codesByDay[day]); ok {
+			wc := int(code)
+			c.WeatherCode = &wc
+		}
+		days[day] = c
+	}
+	return days
+}
+
+// FetchHourlyConditions returns the hourly temperature, humidity and PM2.5 of
+// a location on date (YYYY-MM-DD), in local time
+func FetchHourlyConditions(
+	ctx context.Context,
+	client *http.Client,
+	lat, long float64, date string,
+) ([]*domain.HourlyCondition, error) {
+
+	params := url.Values{}
+	params.Set("start_date", date)
+	params.Set("end_date", date)
+
+	weather, err := FetchHourly(ctx, client, WeatherEndpointFor(date), lat, long,
+		[]string{"temperature_2m", "relative_humidity_2m"}, params)
+	if err != nil {
+		return nil, err
+	}
+	air, err := FetchHourly(ctx, client, AirQualityURL, lat, long, []string{"pm2_5"}, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildHourlyConditions(weather, air), nil
+}
+
+// BuildHourlyConditions joins the weather and air quality series on their
+// timestamps. Hours missing from the air quality series keep a nil PM2.5.
+func BuildHourlyConditions(weather, air *HourlySeries) []*domain.HourlyCondition {
+	pm25 := make(map[int64]*float64, len(air.Time))
+	for i, t := range air.Time {
+		pm25[t.Unix()] = air.Value("pm2_5", i)
+	}
+
+	hours := make([]*domain.HourlyCondition, 0, len(weather.Time))
+	for i, t := range weather.Time {
+		h := &domain.HourlyCondition{
+			Time:             t,
+			Temperature:      weather.Value("temperature_2m", i),
+			RelativeHumidity: weather.Value("relative_humidity_2m", i),
+			PM25:             pm25[t.Unix()],
+		}
+		if h.Temperature != nil && h.RelativeHumidity != nil {
+			hi := HeatIndex(*h.Temperature, *h.RelativeHumidity)
+			h.HeatIndex = &hi
+		}
+		hours = append(hours, h)
+	}
+	return hours
+}
+
+func seriesMean(vals []*float64) (float64, bool) {
+	var (
+		sum   float64
+		count int
+	)
+	for _, v := range vals {
+		if v != nil {
+			sum += *v
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+func seriesSum(vals []*float64) (float64, bool) {
+	var (
+		sum float64
+		ok  bool
+	)
+	for _, v := range vals {
+		if v != nil {
+			sum, ok = sum+*v, true
+		}
+	}
+	return sum, ok
+}
+
+// minDayCoverage is the share of the hours of a local day a daily sum needs,
+// so that missing hours do not pass for dry ones
+const minDayCoverage = 0.75
+
+// daySum sums the values of a local day of series, false when fewer than
+// minDayCoverage of its hours are present
+func daySum(series *HourlySeries, day string, vals []*float64) (float64, bool) {
+	var present int
+	for _, v := range vals {
+		if v != nil {
+			present++
+		}
+	}
+	if float64(present) < minDayCoverage*float64(series.HoursInDay(day)) {
+		return 0, false
+	}
+	return seriesSum(vals)
+}
+
+func seriesMax(vals []*float64) (float64, bool) {
+	m, ok := math.Inf(-1), false
+	for _, v := range vals {
+		if v != nil && *v > m {
+			m, ok = *v, true
+		}
+	}
+	return m, ok
+}
@@ -0,0 +1,77 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDailyTravelConditions(t *testing.T) {
+	weather, err := decodeHourly(strings.NewReader(`{
+		"utc_offset_seconds": 21600,
+		"hourly": {
+			"time": ["2024-01-15T13:00", "2024-01-15T14:00", "2024-01-16T13:00", "2024-01-16T14:00"],
+			"temperature_2m": [24.1, 25.3, 23.0, null],
+			"relative_humidity_2m": [60, 55, 62, 58],
+			"wind_speed_10m": [5.1, 6.2, 4.0, 4.4],
+			"precipitation_probability": [10, 30, 0, 5],
+			"precipitation": [1.5, 2.0, 0, 0],
+			"weather_code": [61, 3, 0, 1]
+		}
+	}`), []string{"temperature_2m", "relative_humidity_2m", "wind_speed_10m", "precipitation_probability", "precipitation", "weather_code"})
+	assert.NoError(t, err)
+
+	air, err := decodeHourly(strings.NewReader(`{
+		"utc_offset_seconds": 21600,
+		"hourly": {
+			"time": ["2024-01-15T13:00", "2024-01-15T14:00", "2024-01-16T14:00"],
+			"pm2_5": [80, 90, 70]
+		}
+	}`), []string{"pm2_5"})
+	assert.NoError(t, err)
+
+	days := BuildDailyTravelConditions(weather, air)
+
+	assert.Len(t, days, 1, "2024-01-16 has no 2PM temperature")
+	c := days["2024-01-15"]
+	if assert.NotNil(t, c) {
+		assert.Equal(t, 25.3, c.Temp2PM)
+		assert.Equal(t, 85.0, c.PM25)
+		assert.Equal(t, 30.0, *c.PrecipitationProbability)
+		assert.Equal(t, 55.0, *c.Humidity2PM)
+		assert.Nil(t, c.PrecipitationSum, "2 of 24 hours are too few for a daily sum")
+		assert.Equal(t, 61, *c.WeatherCode)
+		assert.Equal(t, 172, c.AQI.AQI, "from the 85 µg/m³ daily PM2.5")
+	}
+}
+
+func TestBuildHourlyConditions(t *testing.T) {
+	weather, err := decodeHourly(strings.NewReader(`{
+		"utc_offset_seconds": 21600,
+		"hourly": {
+			"time": ["2024-01-15T13:00", "2024-01-15T14:00"],
+			"temperature_2m": [24.1, 25.3],
+			"relative_humidity_2m": [60, null]
+		}
+	}`), []string{"temperature_2m", "relative_humidity_2m"})
+	assert.NoError(t, err)
+
+	air, err := decodeHourly(strings.NewReader(`{
+		"utc_offset_seconds": 21600,
+		"hourly": {
+			"time": ["2024-01-15T14:00"],
+			"pm2_5": [90]
+		}
+	}`), []string{"pm2_5"})
+	assert.NoError(t, err)
+
+	hours := BuildHourlyConditions(weather, air)
+
+	assert.Len(t, hours, 2)
+	assert.Equal(t, 13, hours[0].Time.Hour())
+	assert.Nil(t, hours[0].PM25, "no air quality sample at 13:00")
+	assert.NotNil(t, hours[0].HeatIndex)
+	assert.Nil(t, hours[1].HeatIndex, "no humidity at 14:00")
+	assert.Equal(t, 90.0, *hours[1].PM25)
+}
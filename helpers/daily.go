@@ -0,0 +1,117 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"travel_advisor/domain"
+)
+
+// sunDailyVars are the daily forecast variables behind domain.DailySun
+var sunDailyVars = []string{"uv_index_max", "sunrise", "sunset", "daylight_duration"}
+
+// FetchDailySun returns the UV index, sunrise, sunset and daylight of every
+// day from the from to the to date (inclusive) from the daily forecast
+func FetchDailySun(
+	ctx context.Context,
+	client *http.Client,
+	lat, long float64, from, to string,
+) ([]*domain.DailySun, error) {
+
+	params := url.Values{}
+	params.Set("start_date", from)
+	params.Set("end_date", to)
+	params.Set("daily", strings.Join(sunDailyVars, ","))
+
+	var days []*domain.DailySun
+	err := requestOpenMeteo(ctx, client, WeatherForecastURL, lat, long, params, func(r io.Reader) (err error) {
+		days, err = decodeDailySun(r)
+		return err
+	})
+	return days, err
+}
+
+// decodeDailySun reads the daily sun variables of a response. Sunrise and
+// sunset are local times resolved in the timezone of the response.
+func decodeDailySun(r io.Reader) ([]*domain.DailySun, error) {
+	var data struct {
+		UTCOffsetSeconds int    `json:"utc_offset_seconds"`
+		Timezone         string `json:"timezone"`
+		Daily            struct {
+			Time             []string   `json:"time"`
+			UVIndexMax       []*float64 `json:"uv_index_max"`
+			Sunrise          []*string  `json:"sunrise"`
+			Sunset           []*string  `json:"sunset"`
+			DaylightDuration []*float64 `json:"daylight_duration"`
+		} `json:"daily"`
+	}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	daily := data.Daily
+	n := len(daily.Time)
+	for i, l := range []int{len(daily.UVIndexMax), len(daily.Sunrise), len(daily.Sunset), len(daily.DaylightDuration)} {
+		if l != n {
+			return nil, fmt.Errorf("open-meteo: daily.%s has %d values for %d days", sunDailyVars[i], l, n)
+		}
+	}
+
+	loc := responseLocation(data.Timezone, data.UTCOffsetSeconds)
+	days := make([]*domain.DailySun, 0, n)
+	for i, date := range daily.Time {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return nil, fmt.Errorf("open-meteo: invalid daily.time %q: %v", date, err)
+		}
+		sunrise, err := parseLocalTime(daily.Sunrise[i], loc)
+		if err != nil {
+			return nil, fmt.Errorf("open-meteo: invalid daily.sunrise: %v", err)
+		}
+		sunset, err := parseLocalTime(daily.Sunset[i], loc)
+		if err != nil {
+			return nil, fmt.Errorf("open-meteo: invalid daily.sunset: %v", err)
+		}
+
+		d := &domain.DailySun{
+			Date:       date,
+			UVIndexMax: daily.UVIndexMax[i],
+			Sunrise:    sunrise,
+			Sunset:     sunset,
+		}
+		if s := daily.DaylightDuration[i]; s != nil {
+			hours := math.Round(*s/36) / 100
+			d.DaylightHours = &hours
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+// parseLocalTime parses an optional ISO8601 local time, nil stays nil
+func parseLocalTime(raw *string, loc *time.Location) (*time.Time, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation(hourlyTimeLayout, *raw, loc)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ApplyDailySun copies the UV index and daylight of each day onto the travel
+// conditions of the same date
+func ApplyDailySun(days map[string]*domain.TravelConditions, sun []*domain.DailySun) {
+	for _, s := range sun {
+		if c, ok := days[s.Date]; ok {
+			c.UVIndexMax = s.UVIndexMax
+			c.DaylightHours = s.DaylightHours
+		}
+	}
+}
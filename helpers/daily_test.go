@@ -0,0 +1,61 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"travel_advisor/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeDailySun(t *testing.T) {
+	days, err := decodeDailySun(strings.NewReader(`{
+		"utc_offset_seconds": 21600,
+		"timezone": "Asia/Dhaka",
+		"daily": {
+			"time": ["2024-05-01", "2024-05-02"],
+			"uv_index_max": [9.45, null],
+			"sunrise": ["2024-05-01T05:21", "2024-05-02T05:20"],
+			"sunset": ["2024-05-01T18:27", null],
+			"daylight_duration": [47160, 47220]
+		}
+	}`))
+
+	assert.NoError(t, err)
+	assert.Len(t, days, 2)
+	assert.Equal(t, "2024-05-01", days[0].Date)
+	assert.Equal(t, 9.45, *days[0].UVIndexMax)
+	assert.Equal(t, time.Date(2024, 4, 30, 23, 21, 0, 0, time.UTC), days[0].Sunrise.UTC())
+	assert.Equal(t, 13.1, *days[0].DaylightHours)
+	assert.Nil(t, days[1].UVIndexMax)
+	assert.Nil(t, days[1].Sunset)
+}
+
+func TestDecodeDailySun_LengthMismatch(t *testing.T) {
+	_, err := decodeDailySun(strings.NewReader(`{
+		"daily": {
+			"time": ["2024-05-01", "2024-05-02"],
+			"uv_index_max": [9.45],
+			"sunrise": [null, null],
+			"sunset": [null, null],
+			"daylight_duration": [null, null]
+		}
+	}`))
+
+	assert.ErrorContains(t, err, "daily.uv_index_max has 1 values for 2 days")
+}
+
+func TestApplyDailySun(t *testing.T) {
+	uv, daylight := 7.2, 12.5
+	days := map[string]*domain.TravelConditions{"2024-05-01": {Temp2PM: 31}}
+
+	ApplyDailySun(days, []*domain.DailySun{
+		{Date: "2024-05-01", UVIndexMax: &uv, DaylightHours: &daylight},
+		{Date: "2024-05-09", UVIndexMax: &uv},
+	})
+
+	assert.Equal(t, &uv, days["2024-05-01"].UVIndexMax)
+	assert.Equal(t, &daylight, days["2024-05-01"].DaylightHours)
+	assert.Len(t, days, 1, "days without travel conditions are not added")
+}
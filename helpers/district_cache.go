@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/cache"
+)
+
+// DistrictCacheTTL is how long a district cache entry is kept, long enough
+// to serve as a fallback through an upstream outage
+const DistrictCacheTTL = 24 * time.Hour
+
+// FetchDistrictCache fetches the weather and air quality of the district over
+// the aggregation horizon, reduces them to a cache entry and keeps the travel
+// conditions of every day of it
+func FetchDistrictCache(
+	ctx context.Context,
+	client *http.Client,
+	d *domain.District,
+	agg Aggregation,
+	now time.Time,
+) (*domain.DistrictCache, error) {
+
+	weatherHourly, err := fetchWeatherHourly(ctx, client, d.Lat, d.Long, nil, agg)
+	if err != nil {
+		return nil, err
+	}
+	weather, err := SummarizeWeather(weatherHourly, agg)
+	if err != nil {
+		return nil, err
+	}
+	airHourly, err := fetchAirQualityHourly(ctx, client, d.Lat, d.Long, nil, agg)
+	if err != nil {
+		return nil, err
+	}
+	air, err := SummarizeAirQuality(airHourly, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DistrictCache{
+		Version:   domain.DistrictCacheVersion,
+		Name:      d.Name,
+		UpdatedAt: now.UTC(),
+		Statistic: string(agg.Statistic),
+		Temp:      weather.Temp,
+		PM25:      air.PM25,
+		TempStats: weather.TempStats,
+		PM25Stats: air.PM25Stats,
+		AQI:       air.AQI,
+
+		Humidity:     weather.Humidity,
+		ApparentTemp: weather.ApparentTemp,
+		WindSpeed:    weather.WindSpeed,
+		HeatIndex:    weather.HeatIndex,
+		WBGT:         weather.WBGT,
+
+		AvgPrecipitationSum:         weather.AvgPrecipitationSum,
+		AvgPrecipitationProbability: weather.AvgPrecipitationProbability,
+		WeatherCode:                 weather.WeatherCode,
+
+		Daily: BuildDailyTravelConditions(weatherHourly, airHourly),
+	}, nil
+}
+
+// StoreDistrictCache writes the entry under the district name
+func StoreDistrictCache(ctx context.Context, c cache.Cache, entry *domain.DistrictCache) error {
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return c.Set(ctx, entry.Name, bytes, DistrictCacheTTL)
+}
@@ -0,0 +1,109 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"travel_advisor/domain"
+)
+
+var ensembleVars = []string{"temperature_2m", "precipitation"}
+
+// DefaultWeatherModel is the model Open-Meteo serves when no models are
+// requested, the travel conditions are forecast with it
+const DefaultWeatherModel = "best_match"
+
+// FetchEnsemble forecasts a location on date (YYYY-MM-DD) with every model
+// and summarizes how far they agree
+func FetchEnsemble(
+	ctx context.Context,
+	client *http.Client,
+	lat, long float64, date string,
+	models []string,
+) (*domain.EnsembleForecast, error) {
+
+	if len(models) < 2 {
+		return nil, errors.New("an ensemble needs at least two models")
+	}
+
+	params := url.Values{}
+	params.Set("start_date", date)
+	params.Set("end_date", date)
+	params.Set("models", strings.Join(models, ","))
+
+	weather, err := fetchHourly(ctx, client, WeatherForecastURL, lat, long, ensembleVars, ensembleKeys(models), params)
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeEnsemble(weather, date, models), nil
+}
+
+// ensembleKeys are the hourly keys of a multi-model response, every variable
+// suffixed with each model
+func ensembleKeys(models []string) []string {
+	keys := make([]string, 0, len(ensembleVars)*len(models))
+	for _, v := range ensembleVars {
+		for _, m := range models {
+			keys = append(keys, v+"_"+m)
+		}
+	}
+	return keys
+}
+
+// SummarizeEnsemble reduces each model to its 2PM temperature and daily
+// precipitation on date, then takes the mean and spread across models.
+// Models without data for a metric are left out of it.
+func SummarizeEnsemble(weather *HourlySeries, date string, models []string) *domain.EnsembleForecast {
+	var temps, rain []float64
+	for _, m := range models {
+		var (
+			temp    *float64
+			dayRain []*float64
+		)
+		for i, t := range weather.Time {
+			if t.Format(time.DateOnly) != date {
+				continue
+			}
+			if t.Hour() == 14 {
+				temp = weather.Value("temperature_2m_"+m, i)
+			}
+			dayRain = append(dayRain, weather.Value("precipitation_"+m, i))
+		}
+		if temp != nil {
+			temps = append(temps, *temp)
+		}
+		if sum, ok := daySum(weather, date, dayRain); ok {
+			rain = append(rain, sum)
+		}
+	}
+
+	return &domain.EnsembleForecast{
+		Models:           models,
+		Temp2PM:          ensembleStat(temps),
+		PrecipitationSum: ensembleStat(rain),
+	}
+}
+
+// ensembleStat is the mean and population standard deviation of the members
+func ensembleStat(members []float64) *domain.EnsembleStat {
+	if len(members) < 2 {
+		return nil
+	}
+	var sum, sq float64
+	for _, v := range members {
+		sum += v
+	}
+	mean := sum / float64(len(members))
+	for _, v := range members {
+		sq += (v - mean) * (v - mean)
+	}
+	return &domain.EnsembleStat{
+		Mean:    mean,
+		Spread:  math.Sqrt(sq / float64(len(members))),
+		Members: len(members),
+	}
+}
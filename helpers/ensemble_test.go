@@ -0,0 +1,44 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeEnsemble(t *testing.T) {
+	models := []string{"ecmwf_ifs025", "gfs_seamless", "icon_seamless"}
+	weather, err := decodeHourly(strings.NewReader(`{
+		"utc_offset_seconds": 21600,
+		"timezone": "Asia/Dhaka",
+		"hourly": {
+			"time": ["2024-05-01T00:00", "2024-05-01T01:00", "2024-05-01T02:00", "2024-05-01T03:00", "2024-05-01T04:00", "2024-05-01T05:00", "2024-05-01T06:00", "2024-05-01T07:00", "2024-05-01T08:00", "2024-05-01T09:00", "2024-05-01T10:00", "2024-05-01T11:00", "2024-05-01T12:00", "2024-05-01T13:00", "2024-05-01T14:00", "2024-05-01T15:00", "2024-05-01T16:00", "2024-05-01T17:00", "2024-05-01T18:00", "2024-05-01T19:00", "2024-05-01T20:00", "2024-05-01T21:00", "2024-05-01T22:00", "2024-05-01T23:00"],
+			"temperature_2m_ecmwf_ifs025": [28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 33.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0, 28.0],
+			"temperature_2m_gfs_seamless": [29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 35.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0, 29.0],
+			"temperature_2m_icon_seamless": [27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, null, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0, 27.0],
+			"precipitation_ecmwf_ifs025": [1.0, 1.0, 1.0, 1.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0],
+			"precipitation_gfs_seamless": [0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0],
+			"precipitation_icon_seamless": [0.5, 0.5, 0.5, 0.5, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, null, null, null, null, null, null, null, null, null, null, null]
+		}
+	}`), ensembleKeys(models))
+	assert.NoError(t, err)
+
+	e := SummarizeEnsemble(weather, "2024-05-01", models)
+
+	assert.Equal(t, models, e.Models)
+	if assert.NotNil(t, e.Temp2PM) {
+		assert.Equal(t, 2, e.Temp2PM.Members, "icon has no 2PM temperature")
+		assert.Equal(t, 34.0, e.Temp2PM.Mean)
+		assert.Equal(t, 1.0, e.Temp2PM.Spread)
+	}
+	if assert.NotNil(t, e.PrecipitationSum) {
+		assert.Equal(t, 2, e.PrecipitationSum.Members, "icon misses too many hours for a daily sum")
+		assert.Equal(t, 2.0, e.PrecipitationSum.Mean)
+		assert.Equal(t, 2.0, e.PrecipitationSum.Spread)
+	}
+}
+
+func TestEnsembleStat_SingleMember(t *testing.T) {
+	assert.Nil(t, ensembleStat([]float64{30}))
+}
@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const GeoJSONMediaType = "application/geo+json"
+
+// GeoJSONResponse renders a bare GeoJSON object, without the usual data envelope,
+// so map clients can consume it directly
+type GeoJSONResponse struct {
+	Status int
+	Data   interface{}
+}
+
+func (r *GeoJSONResponse) Render(w http.ResponseWriter) error {
+	bb, err := json.Marshal(r.Data)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", GeoJSONMediaType)
+	if r.Status != 0 {
+		w.WriteHeader(r.Status)
+	}
+	_, err = w.Write(bb)
+	return err
+}
+
+// AcceptsGeoJSON reports whether the client asked for application/geo+json
+func AcceptsGeoJSON(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mediaType == GeoJSONMediaType {
+			return true
+		}
+	}
+	return false
+}
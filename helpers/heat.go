@@ -0,0 +1,30 @@
+package helpers
+
+import "math"
+
+// HeatIndex returns the NOAA heat index in °C for an air temperature in °C and
+// relative humidity in percent. Below 26.7°C (80°F) the Steadman
+// approximation is used, as the Rothfusz regression is only valid above it.
+func HeatIndex(tempC, rh float64) float64 {
+	t := tempC*9/5 + 32
+	hi := 0.5 * (t + 61.0 + (t-68.0)*1.2 + rh*0.094)
+	if (hi+t)/2 >= 80 {
+		hi = -42.379 + 2.04901523*t + 10.14333127*rh -
+			0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+			0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+		if rh < 13 && t >= 80 && t <= 112 {
+			hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		} else if rh > 85 && t >= 80 && t <= 87 {
+			hi += (rh - 85) / 10 * (87 - t) / 5
+		}
+	}
+	return (hi - 32) * 5 / 9
+}
+
+// WBGT estimates the wet-bulb globe temperature in °C from the air
+// temperature and relative humidity, using the Australian Bureau of
+// Meteorology approximation for shade and light wind
+func WBGT(tempC, rh float64) float64 {
+	vapourPressure := rh / 100 * 6.105 * math.Exp(17.27*tempC/(237.7+tempC))
+	return 0.567*tempC + 0.393*vapourPressure + 3.94
+}
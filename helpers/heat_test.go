@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeatIndex(t *testing.T) {
+	// NOAA table: 90°F at 70% feels like 106°F
+	assert.InDelta(t, 41.1, HeatIndex(32.2, 70), 0.3)
+	// below 80°F the Steadman approximation stays close to the air temperature
+	assert.InDelta(t, 20.5, HeatIndex(21, 50), 0.1)
+}
+
+func TestWBGT(t *testing.T) {
+	assert.InDelta(t, 35.1, WBGT(32, 70), 0.1)
+	assert.Less(t, WBGT(32, 30), WBGT(32, 70), "drier air is less stressful")
+}
+
+func TestSummarizeWeather(t *testing.T) {
+	weather, err := decodeHourly(strings.NewReader(`{
+		"utc_offset_seconds": 21600,
+		"hourly": {
+			"time": ["2024-01-15T13:00", "2024-01-15T14:00", "2024-01-16T14:00"],
+			"temperature_2m": [20, 30, 32],
+			"relative_humidity_2m": [50, 60, null],
+			"apparent_temperature": [19, 33, 35],
+			"wind_speed_10m": [5, 10, 20],
+			"precipitation": [2, 4, 0],
+			"weather_code": [61, 3, 3],
+			"precipitation_probability": [70, 40, 10]
+		}
+	}`), append(weatherSummaryVars, "precipitation_probability"))
+	assert.NoError(t, err)
+
+	s, err := SummarizeWeather(weather, DefaultAggregation)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 31.0, s.Temp)
+	assert.Equal(t, 34.0, *s.ApparentTemp)
+	assert.Equal(t, 15.0, *s.WindSpeed)
+	assert.Equal(t, 60.0, *s.Humidity)
+	assert.InDelta(t, HeatIndex(30, 60), *s.HeatIndex, 1e-9, "only the day with humidity")
+	assert.InDelta(t, WBGT(30, 60), *s.WBGT, 1e-9)
+	assert.Nil(t, s.AvgPrecipitationSum, "partial days have no daily sum")
+	assert.Equal(t, 40.0, *s.AvgPrecipitationProbability, "daily maxima of 70% and 10%")
+	assert.Equal(t, 61, *s.WeatherCode, "ties go to the more severe code")
+}
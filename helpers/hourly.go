@@ -0,0 +1,338 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"travel_advisor/domain"
+)
+
+const (
+	WeatherForecastURL = "https://api.open-meteo.com/v1/forecast"
+	WeatherArchiveURL  = "https://archive-api.open-meteo.com/v1/archive"
+	AirQualityURL      = "https://air-quality-api.open-meteo.com/v1/air-quality"
+
+	// archiveDelayDays is how far behind today the archive API lags; more
+	// recent days are still served by the forecast API
+	archiveDelayDays = 5
+
+	// ForecastHorizonDays is how many days ahead, today included, both the
+	// weather and the air quality forecasts cover
+	ForecastHorizonDays = 7
+
+	// hourlyTimeLayout is the ISO8601 local time format Open-Meteo uses for hourly.time
+	hourlyTimeLayout = "2006-01-02T15:04"
+)
+
+var (
+	weatherObservationVars    = []string{"temperature_2m", "relative_humidity_2m", "precipitation", "wind_speed_10m"}
+	airQualityObservationVars = []string{"pm2_5", "pm10"}
+)
+
+// HourlySeries is an hourly Open-Meteo series with its timestamps resolved
+// to absolute times in Location, the zone of the requested place. Time is
+// strictly increasing but may skip hours the provider did not return.
+type HourlySeries struct {
+	Time     []time.Time
+	Values   map[string][]*float64
+	Location *time.Location
+}
+
+// Gap is a run of hours missing from a series after a sample
+type Gap struct {
+	After time.Time
+	Hours int
+}
+
+// Gaps lists the hours missing between consecutive samples. Local clock
+// changes are not gaps, the series is compared in absolute time.
+func (s *HourlySeries) Gaps() []Gap {
+	var gaps []Gap
+	for i := 1; i < len(s.Time); i++ {
+		if missing := int(s.Time[i].Sub(s.Time[i-1])/time.Hour) - 1; missing > 0 {
+			gaps = append(gaps, Gap{After: s.Time[i-1], Hours: missing})
+		}
+	}
+	return gaps
+}
+
+// HoursInDay is the length of a local day (YYYY-MM-DD) of the series, 23 or
+// 25 on clock changes
+func (s *HourlySeries) HoursInDay(day string) int {
+	start, err := time.ParseInLocation(time.DateOnly, day, s.location())
+	if err != nil {
+		return 24
+	}
+	return int(start.AddDate(0, 0, 1).Sub(start) / time.Hour)
+}
+
+func (s *HourlySeries) location() *time.Location {
+	if s.Location == nil {
+		return time.UTC
+	}
+	return s.Location
+}
+
+// Value returns the value of variable at index i, nil when missing
+func (s *HourlySeries) Value(variable string, i int) *float64 {
+	vals := s.Values[variable]
+	if i < 0 || i >= len(vals) {
+		return nil
+	}
+	return vals[i]
+}
+
+// FetchHourly requests the hourly variables from an Open-Meteo endpoint and
+// parses hourly.time in the timezone of the response
+func FetchHourly(
+	ctx context.Context,
+	client *http.Client,
+	endpoint string,
+	lat, long float64,
+	variables []string,
+	extra url.Values,
+) (*HourlySeries, error) {
+	return fetchHourly(ctx, client, endpoint, lat, long, variables, variables, extra)
+}
+
+// fetchHourly requests variables and decodes the hourly keys of the
+// response, which differ from the variables when several models are requested
+func fetchHourly(
+	ctx context.Context,
+	client *http.Client,
+	endpoint string,
+	lat, long float64,
+	variables, keys []string,
+	extra url.Values,
+) (*HourlySeries, error) {
+
+	params := url.Values{}
+	for k, v := range extra {
+		params[k] = v
+	}
+	params.Set("hourly", strings.Join(variables, ","))
+
+	var series *HourlySeries
+	err := requestOpenMeteo(ctx, client, endpoint, lat, long, params, func(r io.Reader) (err error) {
+		series, err = decodeHourly(r, keys)
+		return err
+	})
+	return series, err
+}
+
+// requestOpenMeteo sends a GET for the location to an Open-Meteo endpoint
+// and hands the body of a successful response to decode. Responses default
+// to the timezone of the location.
+func requestOpenMeteo(
+	ctx context.Context,
+	client *http.Client,
+	endpoint string,
+	lat, long float64,
+	params url.Values,
+	decode func(io.Reader) error,
+) error {
+
+	params.Set("latitude", fmt.Sprintf("%f", lat))
+	params.Set("longitude", fmt.Sprintf("%f", long))
+	if params.Get("timezone") == "" {
+		params.Set("timezone", "auto")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("open-meteo: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return decode(resp.Body)
+}
+
+// decodeHourly resolves the local hourly.time of a response in its IANA
+// timezone, so days spanning a clock change keep their real hours. Without a
+// loadable timezone the single utc_offset_seconds is applied.
+func decodeHourly(r io.Reader, variables []string) (*HourlySeries, error) {
+	var data struct {
+		UTCOffsetSeconds int                        `json:"utc_offset_seconds"`
+		Timezone         string                     `json:"timezone"`
+		Hourly           map[string]json.RawMessage `json:"hourly"`
+	}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	var rawTimes []string
+	if err := json.Unmarshal(data.Hourly["time"], &rawTimes); err != nil {
+		return nil, fmt.Errorf("open-meteo: invalid hourly.time: %v", err)
+	}
+
+	loc := responseLocation(data.Timezone, data.UTCOffsetSeconds)
+	series := &HourlySeries{
+		Time:     make([]time.Time, len(rawTimes)),
+		Values:   make(map[string][]*float64, len(variables)),
+		Location: loc,
+	}
+	for i, raw := range rawTimes {
+		t, err := time.ParseInLocation(hourlyTimeLayout, raw, loc)
+		if err != nil {
+			return nil, fmt.Errorf("open-meteo: invalid hourly.time %q: %v", raw, err)
+		}
+		if i > 0 && !t.After(series.Time[i-1]) {
+			// the hour repeated when clocks fall back parses to one of its two
+			// occurrences, move whichever sample keeps both in order
+			prev := series.Time[i-1]
+			later, earlier := t.Add(time.Hour), prev.Add(-time.Hour)
+			switch {
+			case later.After(prev) && later.Format(hourlyTimeLayout) == raw:
+				t = later
+			case t.Equal(prev) && earlier.Format(hourlyTimeLayout) == raw && (i < 2 || earlier.After(series.Time[i-2])):
+				series.Time[i-1] = earlier
+			default:
+				return nil, fmt.Errorf("open-meteo: hourly.time %q is out of order", raw)
+			}
+		}
+		series.Time[i] = t
+	}
+
+	for _, v := range variables {
+		raw, ok := data.Hourly[v]
+		if !ok {
+			return nil, fmt.Errorf("open-meteo: missing hourly.%s", v)
+		}
+		var vals []*float64
+		if err := json.Unmarshal(raw, &vals); err != nil {
+			return nil, fmt.Errorf("open-meteo: invalid hourly.%s: %v", v, err)
+		}
+		if len(vals) != len(rawTimes) {
+			return nil, fmt.Errorf("open-meteo: hourly.%s has %d values for %d times", v, len(vals), len(rawTimes))
+		}
+		series.Values[v] = vals
+	}
+
+	return series, nil
+}
+
+// responseLocation is the IANA timezone of a response, or its fixed UTC
+// offset when the zone cannot be loaded
+func responseLocation(timezone string, offsetSeconds int) *time.Location {
+	if timezone != "" {
+		if tz, err := time.LoadLocation(timezone); err == nil {
+			return tz
+		}
+	}
+	return time.FixedZone("", offsetSeconds)
+}
+
+// FetchObservations returns the hourly weather and air quality samples of the
+// last pastDays days up to now, merged by timestamp
+func FetchObservations(
+	ctx context.Context,
+	client *http.Client,
+	lat, long float64, pastDays int,
+) ([]*domain.DistrictObservation, error) {
+
+	params := url.Values{}
+	params.Set("past_days", fmt.Sprintf("%d", pastDays))
+	params.Set("forecast_days", "1")
+
+	weather, err := FetchHourly(ctx, client, WeatherForecastURL, lat, long, weatherObservationVars, params)
+	if err != nil {
+		return nil, err
+	}
+	air, err := FetchHourly(ctx, client, AirQualityURL, lat, long, airQualityObservationVars, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return MergeObservations(weather, air, time.Now()), nil
+}
+
+// FetchHistoricalObservations returns the hourly samples between the from and to
+// dates (inclusive, YYYY-MM-DD) from the archive and historical air quality APIs
+func FetchHistoricalObservations(
+	ctx context.Context,
+	client *http.Client,
+	lat, long float64, from, to string,
+) ([]*domain.DistrictObservation, error) {
+
+	params := url.Values{}
+	params.Set("start_date", from)
+	params.Set("end_date", to)
+
+	weather, err := FetchHourly(ctx, client, WeatherEndpointFor(to), lat, long, weatherObservationVars, params)
+	if err != nil {
+		return nil, err
+	}
+	air, err := FetchHourly(ctx, client, AirQualityURL, lat, long, airQualityObservationVars, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return MergeObservations(weather, air, time.Now()), nil
+}
+
+// ArchiveCutoff returns the most recent day the archive API reliably serves
+func ArchiveCutoff() time.Time {
+	return ArchiveCutoffAt(time.Now())
+}
+
+// ArchiveCutoffAt is the ArchiveCutoff as of now
+func ArchiveCutoffAt(now time.Time) time.Time {
+	return now.UTC().Truncate(24*time.Hour).AddDate(0, 0, -archiveDelayDays)
+}
+
+// WeatherEndpointFor picks the archive API for dates up to the archive cutoff,
+// and the forecast API for anything more recent
+func WeatherEndpointFor(date string) string {
+	d, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return WeatherForecastURL
+	}
+	if d.After(ArchiveCutoff()) {
+		return WeatherForecastURL
+	}
+	return WeatherArchiveURL
+}
+
+// MergeObservations joins weather and air quality series on their timestamps,
+// dropping samples after until
+func MergeObservations(weather, air *HourlySeries, until time.Time) []*domain.DistrictObservation {
+	airIdx := make(map[int64]int, len(air.Time))
+	for i, t := range air.Time {
+		airIdx[t.Unix()] = i
+	}
+
+	list := make([]*domain.DistrictObservation, 0, len(weather.Time))
+	for i, t := range weather.Time {
+		if t.After(until) {
+			continue
+		}
+		obs := &domain.DistrictObservation{
+			ObservedAt:       t.UTC(),
+			Temperature:      weather.Value("temperature_2m", i),
+			RelativeHumidity: weather.Value("relative_humidity_2m", i),
+			Precipitation:    weather.Value("precipitation", i),
+			WindSpeed:        weather.Value("wind_speed_10m", i),
+		}
+		if j, ok := airIdx[t.Unix()]; ok {
+			obs.PM25 = air.Value("pm2_5", j)
+			obs.PM10 = air.Value("pm10", j)
+		}
+		list = append(list, obs)
+	}
+	return list
+}
@@ -2,123 +2,161 @@ package helpers
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 	"net/url"
-	"travel_advisor/pkg/log"
+	"strconv"
+	"time"
+	"travel_advisor/domain"
 )
 
-func FetchAvgTempAt2PM(
+var weatherSummaryVars = []string{
+	"temperature_2m", "relative_humidity_2m", "apparent_temperature", "wind_speed_10m",
+	"precipitation", "weather_code",
+}
+
+// WeatherSummary reduces the weather sampled in the hour window of an
+// Aggregation with its statistic. Heat index and WBGT are derived per
+// sample; metrics without data are nil.
+// Precipitation is summed, and its probability maxed, per day before
+// averaging; WeatherCode is the most frequent daily code.
+type WeatherSummary struct {
+	Temp         float64
+	TempStats    *domain.MetricStats
+	Humidity     *float64
+	ApparentTemp *float64
+	WindSpeed    *float64
+	HeatIndex    *float64
+	WBGT         *float64
+
+	AvgPrecipitationSum         *float64
+	AvgPrecipitationProbability *float64
+	WeatherCode                 *int
+}
+
+// FetchWeatherSummary returns the weather summary of date or, without one,
+// of the aggregation horizon
+func FetchWeatherSummary(
 	ctx context.Context,
 	client *http.Client,
 	lat, long float64, date *string,
-) (float64, error) {
-
-	params := url.Values{}
-	params.Set("latitude", fmt.Sprintf("%f", lat))
-	params.Set("longitude", fmt.Sprintf("%f", long))
-	params.Set("hourly", "temperature_2m")
-	params.Set("timezone", "auto")
-	if date != nil {
-		params.Set("start_date", *date)
-		params.Set("end_date", *date)
-	} else {
-		params.Set("forecast_days", "7")
-	}
-
-	weatherURL := "https://api.open-meteo.com/v1/forecast?" + params.Encode()
+	agg Aggregation,
+) (*WeatherSummary, error) {
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, weatherURL, nil)
+	weather, err := fetchWeatherHourly(ctx, client, lat, long, date, agg)
 	if err != nil {
-		return 0, err
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	var data struct {
-		Hourly struct {
-			Temperature []float64 `json:"temperature_2m"`
-		} `json:"hourly"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return 0, err
+		return nil, err
 	}
-
-	temps := data.Hourly.Temperature
-	log.Println(temps)
-
-	var sum float64
-	var count int
-	for i := 14; i < len(temps); i += 24 {
-		sum += temps[i]
-		count++
-	}
-
-	if count == 0 {
-		return 0, errors.New("no temperature data")
-	}
-
-	return sum / float64(count), nil
+	return SummarizeWeather(weather, agg)
 }
 
-func FetchAvgPM25(
+// fetchWeatherHourly fetches the summary variables of date or, without one,
+// of the aggregation horizon
+func fetchWeatherHourly(
 	ctx context.Context,
 	client *http.Client,
 	lat, long float64, date *string,
-) (float64, error) {
+	agg Aggregation,
+) (*HourlySeries, error) {
 
 	params := url.Values{}
-	params.Set("latitude", fmt.Sprintf("%f", lat))
-	params.Set("longitude", fmt.Sprintf("%f", long))
-	params.Set("hourly", "pm2_5")
-	params.Set("timezone", "auto")
-
+	endpoint := WeatherForecastURL
 	if date != nil {
 		params.Set("start_date", *date)
 		params.Set("end_date", *date)
+		endpoint = WeatherEndpointFor(*date)
 	} else {
-		params.Set("forecast_days", "7")
+		params.Set("forecast_days", strconv.Itoa(agg.HorizonDays))
 	}
 
-	airURL := "https://air-quality-api.open-meteo.com/v1/air-quality?" + params.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, airURL, nil)
-	if err != nil {
-		return 0, err
+	variables := weatherSummaryVars
+	if endpoint == WeatherForecastURL {
+		// the archive has no probabilistic variables
+		variables = append(variables[:len(variables):len(variables)], "precipitation_probability")
 	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
+	return FetchHourly(ctx, client, endpoint, lat, long, variables, params)
+}
 
-	var data struct {
-		Hourly struct {
-			PM25 []float64 `json:"pm2_5"`
-		} `json:"hourly"`
+// SummarizeWeather reduces the samples taken in the hour window of agg
+func SummarizeWeather(weather *HourlySeries, agg Aggregation) (*WeatherSummary, error) {
+	var temps, humidity, apparent, wind, heat, wbgt []*float64
+	for i, t := range weather.Time {
+		if !agg.Includes(t) {
+			continue
+		}
+		temp, rh := weather.Value("temperature_2m", i), weather.Value("relative_humidity_2m", i)
+		temps = append(temps, temp)
+		humidity = append(humidity, rh)
+		apparent = append(apparent, weather.Value("apparent_temperature", i))
+		wind = append(wind, weather.Value("wind_speed_10m", i))
+		if temp != nil && rh != nil {
+			hi, wb := HeatIndex(*temp, *rh), WBGT(*temp, *rh)
+			heat = append(heat, &hi)
+			wbgt = append(wbgt, &wb)
+		}
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return 0, err
+	tempStats, ok := summarize(temps)
+	if !ok {
+		return nil, errors.New("no temperature data")
 	}
 
-	log.Println(data.Hourly.PM25)
-	var sum float64
-	for _, v := range data.Hourly.PM25 {
-		sum += v
+	var (
+		byDay       = make(map[string][]int)
+		order       []string
+		rain, probs []*float64
+		codes       = make(map[int]int)
+	)
+	for i, t := range weather.Time {
+		day := t.Format(time.DateOnly)
+		if _, ok := byDay[day]; !ok {
+			order = append(order, day)
+		}
+		byDay[day] = append(byDay[day], i)
 	}
-
-	if len(data.Hourly.PM25) == 0 {
-		return 0, errors.New("no PM2.5 data")
+	for _, day := range order {
+		var dayRain, dayProbs, dayCodes []*float64
+		for _, i := range byDay[day] {
+			dayRain = append(dayRain, weather.Value("precipitation", i))
+			dayProbs = append(dayProbs, weather.Value("precipitation_probability", i))
+			dayCodes = append(dayCodes, weather.Value("weather_code", i))
+		}
+		rain = append(rain, optional(daySum(weather, day, dayRain)))
+		probs = append(probs, optional(seriesMax(dayProbs)))
+		if code, ok := seriesMax(dayCodes); ok {
+			codes[int(code)]++
+		}
 	}
 
-	return sum / float64(len(data.Hourly.PM25)), nil
+	return &WeatherSummary{
+		AvgPrecipitationSum:         optional(seriesMean(rain)),
+		AvgPrecipitationProbability: optional(seriesMean(probs)),
+		WeatherCode:                 mostFrequent(codes),
+
+		Temp:         tempStats.Value(agg.Statistic),
+		TempStats:    metricStats(tempStats),
+		Humidity:     aggregate(humidity, agg.Statistic),
+		ApparentTemp: aggregate(apparent, agg.Statistic),
+		WindSpeed:    aggregate(wind, agg.Statistic),
+		HeatIndex:    aggregate(heat, agg.Statistic),
+		WBGT:         aggregate(wbgt, agg.Statistic),
+	}, nil
+}
+
+// mostFrequent returns the code seen most often, the more severe one on ties
+func mostFrequent(counts map[int]int) *int {
+	var (
+		best  int
+		found bool
+	)
+	for code, n := range counts {
+		if !found || n > counts[best] || (n == counts[best] && code > best) {
+			best, found = code, true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &best
 }
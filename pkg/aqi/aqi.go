@@ -0,0 +1,232 @@
+// Package aqi computes air quality indices from pollutant concentrations
+package aqi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+type Standard string
+
+const (
+	USEPA         Standard = "us_epa"
+	BangladeshDoE Standard = "bd_doe"
+)
+
+type Pollutant string
+
+const (
+	PM25 Pollutant = "pm2_5"
+	PM10 Pollutant = "pm10"
+	O3   Pollutant = "o3"
+	NO2  Pollutant = "no2"
+	SO2  Pollutant = "so2"
+	CO   Pollutant = "co"
+)
+
+var (
+	ErrUnknownStandard = errors.New("aqi: unknown standard")
+	ErrNoData          = errors.New("aqi: no pollutant concentration")
+)
+
+// Concentrations are in µg/m³, as Open-Meteo reports them, already reduced
+// to the averaging period of each pollutant: the 24 hour mean of PM2.5 and
+// PM10, the highest 8 hour mean of O3 and CO and the highest hourly NO2 and SO2
+type Concentrations struct {
+	PM25 *float64
+	PM10 *float64
+	O3   *float64
+	NO2  *float64
+	SO2  *float64
+	CO   *float64
+}
+
+// SubIndex is the index of a single pollutant, Concentration is in Unit
+type SubIndex struct {
+	Pollutant     Pollutant `json:"pollutant"`
+	Concentration float64   `json:"concentration"`
+	Unit          string    `json:"unit"`
+	AQI           int       `json:"aqi"`
+}
+
+// Result is the overall index, the highest of the sub-indices
+type Result struct {
+	Standard      Standard   `json:"standard"`
+	AQI           int        `json:"aqi"`
+	Category      string     `json:"category"`
+	HealthMessage string     `json:"health_message"`
+	Dominant      Pollutant  `json:"dominant_pollutant"`
+	SubIndices    []SubIndex `json:"sub_indices"`
+}
+
+// ParseStandard validates a configured standard name
+func ParseStandard(s string) (Standard, error) {
+	std := Standard(s)
+	if _, ok := schemes[std]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownStandard, s)
+	}
+	return std, nil
+}
+
+// Calculate returns the index of every known pollutant and the overall index
+func Calculate(std Standard, c Concentrations) (*Result, error) {
+	sc, ok := schemes[std]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStandard, std)
+	}
+
+	res := &Result{Standard: std}
+	for _, p := range []struct {
+		pollutant Pollutant
+		value     *float64
+	}{
+		{PM25, c.PM25}, {PM10, c.PM10}, {O3, c.O3}, {NO2, c.NO2}, {SO2, c.SO2}, {CO, c.CO},
+	} {
+		if p.value == nil {
+			continue
+		}
+		tb := sc.tables[p.pollutant]
+		conc := tb.convert(*p.value)
+		sub := SubIndex{
+			Pollutant:     p.pollutant,
+			Concentration: conc,
+			Unit:          tb.unit,
+			AQI:           tb.index(conc),
+		}
+		res.SubIndices = append(res.SubIndices, sub)
+		if len(res.SubIndices) == 1 || sub.AQI > res.AQI {
+			res.AQI, res.Dominant = sub.AQI, sub.Pollutant
+		}
+	}
+	if len(res.SubIndices) == 0 {
+		return nil, ErrNoData
+	}
+
+	cat := sc.category(res.AQI)
+	res.Category, res.HealthMessage = cat.name, cat.message
+	return res, nil
+}
+
+type breakpoint struct {
+	cLow, cHigh float64
+	iLow, iHigh int
+}
+
+// table holds the breakpoints of a pollutant in its reporting unit.
+// Concentrations are truncated to precision before the lookup.
+type table struct {
+	unit      string
+	molWeight float64
+	precision float64
+	bps       []breakpoint
+}
+
+// convert turns µg/m³ into the unit of the table, gases assume 25°C and 1 atm
+func (tb table) convert(ugm3 float64) float64 {
+	v := ugm3
+	switch tb.unit {
+	case "ppb":
+		v = ugm3 * 24.45 / tb.molWeight
+	case "ppm":
+		v = ugm3 * 24.45 / tb.molWeight / 1000
+	}
+	return math.Floor(v/tb.precision+1e-9) * tb.precision
+}
+
+func (tb table) index(c float64) int {
+	if c <= 0 {
+		return 0
+	}
+	for _, bp := range tb.bps {
+		if c <= bp.cHigh+1e-9 {
+			if c < bp.cLow {
+				c = bp.cLow
+			}
+			i := float64(bp.iHigh-bp.iLow)/(bp.cHigh-bp.cLow)*(c-bp.cLow) + float64(bp.iLow)
+			return int(math.Round(i))
+		}
+	}
+	return tb.bps[len(tb.bps)-1].iHigh
+}
+
+type category struct {
+	max     int
+	name    string
+	message string
+}
+
+type scheme struct {
+	tables     map[Pollutant]table
+	categories []category
+}
+
+func (s scheme) category(aqi int) category {
+	for _, c := range s.categories {
+		if aqi <= c.max {
+			return c
+		}
+	}
+	return s.categories[len(s.categories)-1]
+}
+
+// US EPA bands, PM2.5 as revised in 2024. The 8 hour O3 table stops at 300,
+// above it the 1 hour bands are applied to the 8 hour mean.
+var (
+	usPM25 = table{unit: "µg/m³", precision: 0.1, bps: []breakpoint{
+		{0, 9.0, 0, 50}, {9.1, 35.4, 51, 100}, {35.5, 55.4, 101, 150},
+		{55.5, 125.4, 151, 200}, {125.5, 225.4, 201, 300}, {225.5, 325.4, 301, 500},
+	}}
+	usPM10 = table{unit: "µg/m³", precision: 1, bps: []breakpoint{
+		{0, 54, 0, 50}, {55, 154, 51, 100}, {155, 254, 101, 150},
+		{255, 354, 151, 200}, {355, 424, 201, 300}, {425, 604, 301, 500},
+	}}
+	usO3 = table{unit: "ppm", molWeight: 48.00, precision: 0.001, bps: []breakpoint{
+		{0, 0.054, 0, 50}, {0.055, 0.070, 51, 100}, {0.071, 0.085, 101, 150},
+		{0.086, 0.105, 151, 200}, {0.106, 0.200, 201, 300}, {0.405, 0.604, 301, 500},
+	}}
+	usNO2 = table{unit: "ppb", molWeight: 46.01, precision: 1, bps: []breakpoint{
+		{0, 53, 0, 50}, {54, 100, 51, 100}, {101, 360, 101, 150},
+		{361, 649, 151, 200}, {650, 1249, 201, 300}, {1250, 2049, 301, 500},
+	}}
+	usSO2 = table{unit: "ppb", molWeight: 64.07, precision: 1, bps: []breakpoint{
+		{0, 35, 0, 50}, {36, 75, 51, 100}, {76, 185, 101, 150},
+		{186, 304, 151, 200}, {305, 604, 201, 300}, {605, 1004, 301, 500},
+	}}
+	usCO = table{unit: "ppm", molWeight: 28.01, precision: 0.1, bps: []breakpoint{
+		{0, 4.4, 0, 50}, {4.5, 9.4, 51, 100}, {9.5, 12.4, 101, 150},
+		{12.5, 15.4, 151, 200}, {15.5, 30.4, 201, 300}, {30.5, 50.4, 301, 500},
+	}}
+
+	// bdPM25 are the Department of Environment PM2.5 bands; the DoE follows
+	// the US EPA bands for the other pollutants
+	bdPM25 = table{unit: "µg/m³", precision: 0.1, bps: []breakpoint{
+		{0, 15.4, 0, 50}, {15.5, 65.4, 51, 100}, {65.5, 150.4, 101, 150},
+		{150.5, 250.4, 151, 200}, {250.5, 350.4, 201, 300}, {350.5, 500.4, 301, 500},
+	}}
+)
+
+var schemes = map[Standard]scheme{
+	USEPA: {
+		tables: map[Pollutant]table{PM25: usPM25, PM10: usPM10, O3: usO3, NO2: usNO2, SO2: usSO2, CO: usCO},
+		categories: []category{
+			{50, "Good", "Air quality is satisfactory and poses little or no risk."},
+			{100, "Moderate", "Air quality is acceptable, unusually sensitive people should consider limiting prolonged outdoor exertion."},
+			{150, "Unhealthy for Sensitive Groups", "Sensitive groups may experience health effects, the general public is less likely to be affected."},
+			{200, "Unhealthy", "Some members of the general public may experience health effects, sensitive groups more seriously."},
+			{300, "Very Unhealthy", "Health alert: the risk of health effects is increased for everyone."},
+			{500, "Hazardous", "Health warning of emergency conditions: everyone is more likely to be affected."},
+		},
+	},
+	BangladeshDoE: {
+		tables: map[Pollutant]table{PM25: bdPM25, PM10: usPM10, O3: usO3, NO2: usNO2, SO2: usSO2, CO: usCO},
+		categories: []category{
+			{50, "Good", "Satisfactory air with little or no health risk."},
+			{100, "Moderate", "Acceptable air, unusually sensitive people should limit prolonged outdoor exertion."},
+			{150, "Caution", "Children, older adults and people with heart or lung disease should limit prolonged outdoor exertion."},
+			{200, "Unhealthy", "Everyone may experience health effects, sensitive groups should avoid outdoor exertion."},
+			{300, "Very Unhealthy", "Health alert: everyone should avoid prolonged outdoor exertion."},
+			{500, "Extremely Unhealthy", "Health warning of emergency conditions: everyone should avoid outdoor activity."},
+		},
+	},
+}
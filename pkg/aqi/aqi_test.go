@@ -0,0 +1,68 @@
+package aqi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func TestCalculate_SubIndices(t *testing.T) {
+	tests := []struct {
+		name     string
+		std      Standard
+		conc     Concentrations
+		expected int
+		category string
+	}{
+		{name: "US PM2.5 top of good", std: USEPA, conc: Concentrations{PM25: ptr(9.0)}, expected: 50, category: "Good"},
+		{name: "US PM2.5 truncated into moderate", std: USEPA, conc: Concentrations{PM25: ptr(35.49)}, expected: 100, category: "Moderate"},
+		{name: "US PM2.5 unhealthy", std: USEPA, conc: Concentrations{PM25: ptr(55.5)}, expected: 151, category: "Unhealthy"},
+		{name: "US PM2.5 beyond the scale", std: USEPA, conc: Concentrations{PM25: ptr(900)}, expected: 500, category: "Hazardous"},
+		{name: "US PM10", std: USEPA, conc: Concentrations{PM10: ptr(154)}, expected: 100, category: "Moderate"},
+		// 10000 µg/m³ CO is 8.7 ppm
+		{name: "US CO from µg/m³", std: USEPA, conc: Concentrations{CO: ptr(10000)}, expected: 93, category: "Moderate"},
+		// 100 µg/m³ NO2 is 53 ppb
+		{name: "US NO2 from µg/m³", std: USEPA, conc: Concentrations{NO2: ptr(100)}, expected: 50, category: "Good"},
+		{name: "DoE PM2.5 moderate", std: BangladeshDoE, conc: Concentrations{PM25: ptr(65.4)}, expected: 100, category: "Moderate"},
+		{name: "DoE PM2.5 caution", std: BangladeshDoE, conc: Concentrations{PM25: ptr(100)}, expected: 121, category: "Caution"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Calculate(tt.std, tt.conc)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, res.AQI)
+			assert.Equal(t, tt.category, res.Category)
+			assert.NotEmpty(t, res.HealthMessage)
+		})
+	}
+}
+
+func TestCalculate_Dominant(t *testing.T) {
+	res, err := Calculate(USEPA, Concentrations{PM25: ptr(40), PM10: ptr(60), O3: ptr(50)})
+
+	assert.NoError(t, err)
+	assert.Len(t, res.SubIndices, 3)
+	assert.Equal(t, PM25, res.Dominant)
+	assert.Equal(t, 112, res.AQI)
+}
+
+func TestCalculate_Errors(t *testing.T) {
+	_, err := Calculate(USEPA, Concentrations{})
+	assert.ErrorIs(t, err, ErrNoData)
+
+	_, err = Calculate("who", Concentrations{PM25: ptr(10)})
+	assert.ErrorIs(t, err, ErrUnknownStandard)
+
+	_, err = ParseStandard("who")
+	assert.ErrorIs(t, err, ErrUnknownStandard)
+
+	std, err := ParseStandard("bd_doe")
+	assert.NoError(t, err)
+	assert.Equal(t, BangladeshDoE, std)
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+	"travel_advisor/dependencies"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/conn"
+	"travel_advisor/pkg/log"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	backfillFrom        string
+	backfillTo          string
+	backfillDistrict    string
+	backfillConcurrency int
+	backfillChunkDays   int
+
+	backfillCmd = &cobra.Command{
+		Use:   "backfill",
+		Short: "Backfill historical weather and air quality observations",
+		Long: `Backfill historical weather and air quality observations from the archive APIs.
+Completed district days are recorded, so an interrupted run resumes where it stopped`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println("--------Database is connecting-------")
+			if err := conn.ConnectDefaultDB(); err != nil {
+				return fmt.Errorf("failed to connect to database: %v", err)
+			}
+			log.Info("Database connected successfully!")
+			return nil
+		},
+		RunE: backfill,
+	}
+)
+
+// backfillJob is a contiguous run of days still to be fetched for one district
+type backfillJob struct {
+	district *domain.District
+	days     []time.Time
+}
+
+func init() {
+	backfillCmd.Flags().StringVar(&backfillFrom, "from", "", "first day to backfill (YYYY-MM-DD)")
+	backfillCmd.Flags().StringVar(&backfillTo, "to", "", "last day to backfill (YYYY-MM-DD)")
+	backfillCmd.Flags().StringVar(&backfillDistrict, "district", "", "only backfill this district")
+	backfillCmd.Flags().IntVar(&backfillConcurrency, "concurrency", 4, "maximum concurrent upstream requests")
+	backfillCmd.Flags().IntVar(&backfillChunkDays, "chunk-days", 31, "maximum days fetched per request")
+	backfillCmd.MarkFlagRequired("from")
+	backfillCmd.MarkFlagRequired("to")
+	rootCmd.AddCommand(backfillCmd)
+}
+
+func backfill(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	from, err := time.Parse(time.DateOnly, backfillFrom)
+	if err != nil {
+		return fmt.Errorf("invalid --from: %v", err)
+	}
+	to, err := time.Parse(time.DateOnly, backfillTo)
+	if err != nil {
+		return fmt.Errorf("invalid --to: %v", err)
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if to.Before(from) || !to.Before(today) {
+		return errors.New("--from must not be after --to, and --to must be before today")
+	}
+	if backfillConcurrency < 1 || backfillChunkDays < 1 {
+		return errors.New("--concurrency and --chunk-days must be positive")
+	}
+
+	repositories := dependencies.InjectRepositories()
+	ctr := &domain.DistrictCriteria{}
+	if backfillDistrict != "" {
+		ctr.DistrictName = &backfillDistrict
+	}
+	districts, err := repositories.Districts.List(ctx, ctr)
+	if err != nil {
+		return err
+	}
+	if len(districts) == 0 {
+		return domain.ErrDistrictNotFound
+	}
+
+	var jobs []backfillJob
+	for _, d := range districts {
+		done, err := repositories.Backfill.CompletedDays(ctx, d.ID, from, to)
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, planBackfill(d, from, to, done, backfillChunkDays, helpers.ArchiveCutoff())...)
+	}
+	if len(jobs) == 0 {
+		log.Info("Nothing to backfill, every requested day is already stored")
+		return nil
+	}
+	log.Info("Backfilling %d chunks for %d districts", len(jobs), len(districts))
+
+	client := conn.GetHTTClient()
+
+	var (
+		failed int32
+		wg     sync.WaitGroup
+		queue  = make(chan backfillJob)
+	)
+	for i := 0; i < backfillConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				if err := runBackfillJob(ctx, client, repositories, job); err != nil {
+					atomic.AddInt32(&failed, 1)
+					log.Warn("backfill failed ", job.district.Name, " ", job.days[0].Format(time.DateOnly), ": ", err)
+					continue
+				}
+				log.Info("Backfilled %s %s..%s", job.district.Name,
+					job.days[0].Format(time.DateOnly), job.days[len(job.days)-1].Format(time.DateOnly))
+			}
+		}()
+	}
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d chunks failed, rerun the same command to resume", failed, len(jobs))
+	}
+	log.Info("Backfill completed successfully")
+	return nil
+}
+
+func runBackfillJob(ctx context.Context, client *http.Client, repositories dependencies.RepositoryInterfaces, job backfillJob) error {
+	first := job.days[0].Format(time.DateOnly)
+	last := job.days[len(job.days)-1].Format(time.DateOnly)
+
+	observations, err := helpers.FetchHistoricalObservations(ctx, client, job.district.Lat, job.district.Long, first, last)
+	if err != nil {
+		return err
+	}
+	for _, o := range observations {
+		o.DistrictID = job.district.ID
+	}
+	if err := repositories.Observations.Upsert(ctx, observations); err != nil {
+		return err
+	}
+	return repositories.Backfill.MarkCompleted(ctx, job.district.ID, job.days)
+}
+
+// planBackfill groups the days not yet done into chunks of at most chunkDays
+// consecutive days. Chunks never straddle the archive cutoff since days on
+// either side are served by different endpoints.
+func planBackfill(d *domain.District, from, to time.Time, done map[string]bool, chunkDays int, cutoff time.Time) []backfillJob {
+	var (
+		jobs    []backfillJob
+		current []time.Time
+	)
+	flush := func() {
+		if len(current) > 0 {
+			jobs = append(jobs, backfillJob{district: d, days: current})
+			current = nil
+		}
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if done[day.Format(time.DateOnly)] {
+			flush()
+			continue
+		}
+		if len(current) > 0 && (len(current) == chunkDays || day.After(cutoff) != current[0].After(cutoff)) {
+			flush()
+		}
+		current = append(current, day)
+	}
+	flush()
+	return jobs
+}
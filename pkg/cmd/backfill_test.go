@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+	"travel_advisor/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanBackfill(t *testing.T) {
+	d := &domain.District{ID: 47, Name: "Dhaka"}
+	day := func(s string) time.Time {
+		t, _ := time.Parse(time.DateOnly, s)
+		return t
+	}
+	ranges := func(jobs []backfillJob) [][2]string {
+		out := make([][2]string, 0, len(jobs))
+		for _, j := range jobs {
+			out = append(out, [2]string{j.days[0].Format(time.DateOnly), j.days[len(j.days)-1].Format(time.DateOnly)})
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		done     map[string]bool
+		cutoff   string
+		expected [][2]string
+	}{
+		{
+			name:   "Chunks by size",
+			cutoff: "2030-01-01",
+			expected: [][2]string{
+				{"2024-01-01", "2024-01-04"},
+				{"2024-01-05", "2024-01-08"},
+				{"2024-01-09", "2024-01-10"},
+			},
+		},
+		{
+			name:   "Skips completed days",
+			done:   map[string]bool{"2024-01-03": true, "2024-01-04": true, "2024-01-10": true},
+			cutoff: "2030-01-01",
+			expected: [][2]string{
+				{"2024-01-01", "2024-01-02"},
+				{"2024-01-05", "2024-01-08"},
+				{"2024-01-09", "2024-01-09"},
+			},
+		},
+		{
+			name:   "Splits at the archive cutoff",
+			cutoff: "2024-01-02",
+			expected: [][2]string{
+				{"2024-01-01", "2024-01-02"},
+				{"2024-01-03", "2024-01-06"},
+				{"2024-01-07", "2024-01-10"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := planBackfill(d, day("2024-01-01"), day("2024-01-10"), tt.done, 4, day(tt.cutoff))
+
+			assert.Equal(t, tt.expected, ranges(jobs))
+		})
+	}
+}
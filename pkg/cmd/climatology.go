@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"travel_advisor/dependencies"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/conn"
+	"travel_advisor/pkg/log"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	climatologyWindow int
+
+	climatologyCmd = &cobra.Command{
+		Use:   "climatology",
+		Short: "Compute per district climate normals from stored observations",
+		Long: `Compute per district, per day-of-year normals (mean and standard deviation of
+the 2PM temperature and daily PM2.5) from stored observations`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println("--------Database is connecting-------")
+			if err := conn.ConnectDefaultDB(); err != nil {
+				return fmt.Errorf("failed to connect to database: %v", err)
+			}
+			log.Info("Database connected successfully!")
+			return nil
+		},
+		RunE: climatology,
+	}
+)
+
+func init() {
+	climatologyCmd.Flags().IntVar(&climatologyWindow, "window", 7, "days on either side of a day of the year pooled into its normal")
+	rootCmd.AddCommand(climatologyCmd)
+}
+
+func climatology(cmd *cobra.Command, args []string) error {
+	if climatologyWindow < 0 || climatologyWindow > 60 {
+		return errors.New("--window must be between 0 and 60")
+	}
+
+	repositories := dependencies.InjectRepositories()
+	rows, err := repositories.ClimateNormals.Recompute(cmd.Context(), climatologyWindow, config.App().Timezone)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Climate normals updated, %d district days written", rows)
+	return nil
+}
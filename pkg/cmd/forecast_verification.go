@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"travel_advisor/dependencies"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/conn"
+	"travel_advisor/pkg/log"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// defaultVerifyCronExpr verifies once an hour, away from the top of the hour refresh
+	defaultVerifyCronExpr = "15 * * * *"
+
+	// verifyWindowDays is how long a target day is retried once the archive
+	// should serve it. Days the archive never answers for are given up.
+	verifyWindowDays = 14
+)
+
+// snapshotSchedule remembers the hour each district was last snapshotted in.
+// The cache refresh runs more often than the hourly snapshot keys.
+type snapshotSchedule struct {
+	mu   sync.Mutex
+	last map[int64]time.Time
+}
+
+// claim reports whether the district has no snapshot in the hour of now yet
+// and takes that hour for it
+func (s *snapshotSchedule) claim(districtID int64, now time.Time) bool {
+	hour := now.Truncate(time.Hour)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last == nil {
+		s.last = make(map[int64]time.Time)
+	}
+	if s.last[districtID].Equal(hour) {
+		return false
+	}
+	s.last[districtID] = hour
+	return true
+}
+
+// release gives up the claim of a failed snapshot, so the next refresh retries it
+func (s *snapshotSchedule) release(districtID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.last, districtID)
+}
+
+// snapshotForecasts stores the daily forecasts of a fresh cache entry, issued
+// at the current hour
+func snapshotForecasts(
+	ctx context.Context,
+	repositories dependencies.RepositoryInterfaces,
+	d *domain.District,
+	entry *domain.DistrictCache,
+	now time.Time,
+) error {
+
+	if len(entry.Daily) == 0 {
+		return nil
+	}
+	return repositories.Forecasts.Upsert(ctx, buildForecastSnapshots(d.ID, now.In(appLocation()), entry.Daily))
+}
+
+// buildForecastSnapshots keys the daily forecasts by the hour they were
+// issued at, so refreshes within the same hour overwrite each other
+func buildForecastSnapshots(districtID int64, issuedAt time.Time, days map[string]*domain.TravelConditions) []*domain.ForecastSnapshot {
+	issueDay, _ := time.Parse(time.DateOnly, issuedAt.Format(time.DateOnly))
+
+	snapshots := make([]*domain.ForecastSnapshot, 0, len(days))
+	for date, c := range days {
+		target, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			continue
+		}
+		temp, pm25 := c.Temp2PM, c.PM25
+		snapshots = append(snapshots, &domain.ForecastSnapshot{
+			DistrictID: districtID,
+			IssuedAt:   issuedAt.Truncate(time.Hour),
+			TargetDate: target,
+			LeadDays:   int(target.Sub(issueDay).Hours() / 24),
+			Temp2PM:    &temp,
+			PM25:       &pm25,
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].TargetDate.Before(snapshots[j].TargetDate)
+	})
+	return snapshots
+}
+
+func ScheduleForecastVerification(ctx context.Context, cfg config.SchedulerCfg, repositories dependencies.RepositoryInterfaces) error {
+	expr := cfg.VerifyCronExpr
+	if expr == "" {
+		expr = defaultVerifyCronExpr
+	}
+
+	s := cron.New()
+	_, err := s.AddFunc(expr, func() {
+		verified, err := verifyForecasts(ctx, repositories, time.Now())
+		if err != nil {
+			log.Warn("forecast verification failed ", err)
+			return
+		}
+		log.Info("verified forecasts of %d district days", verified)
+	})
+	if err != nil {
+		log.Error(ctx, "Error adding cron job: %v", err)
+		return err
+	}
+	s.Start()
+
+	return nil
+}
+
+// verifyForecasts fetches the observed values of every district day with
+// unverified snapshots that the archive already serves, and records them.
+// More recent days would be answered by the forecast API, so they wait.
+// Districts failing to fetch are retried on the next run, for at most
+// verifyWindowDays.
+func verifyForecasts(ctx context.Context, repositories dependencies.RepositoryInterfaces, now time.Time) (int, error) {
+	before := verifiableBefore(now)
+	targets, err := repositories.Forecasts.Pending(ctx, before.AddDate(0, 0, -verifyWindowDays), before)
+	if err != nil {
+		return 0, err
+	}
+	if len(targets) == 0 {
+		return 0, nil
+	}
+
+	districts, err := repositories.Districts.List(ctx, &domain.DistrictCriteria{})
+	if err != nil {
+		return 0, err
+	}
+	byID := make(map[int64]*domain.District, len(districts))
+	for _, d := range districts {
+		byID[d.ID] = d
+	}
+
+	client := conn.GetHTTClient()
+
+	var verified int
+	for districtID, dates := range groupForecastTargets(targets) {
+		d, ok := byID[districtID]
+		if !ok {
+			continue
+		}
+		observed, err := helpers.FetchDailyTravelConditions(ctx, client, d.Lat, d.Long, dates[0], dates[len(dates)-1], false)
+		if err != nil {
+			log.Warn("observed conditions fetch failed ", d.Name, err)
+			continue
+		}
+		for _, date := range dates {
+			c, ok := observed[date]
+			if !ok {
+				continue
+			}
+			target, _ := time.Parse(time.DateOnly, date)
+			if err := repositories.Forecasts.Verify(ctx, districtID, target, &c.Temp2PM, &c.PM25); err != nil {
+				log.Warn("forecast verification failed ", d.Name, date, err)
+				continue
+			}
+			verified++
+		}
+	}
+	return verified, nil
+}
+
+// verifiableBefore is the day after the archive cutoff, targets before it
+// are verified against the archive
+func verifiableBefore(now time.Time) time.Time {
+	return helpers.ArchiveCutoffAt(now).AddDate(0, 0, 1)
+}
+
+// groupForecastTargets lists the target dates (YYYY-MM-DD) of each district, oldest first
+func groupForecastTargets(targets []domain.ForecastTarget) map[int64][]string {
+	byDistrict := make(map[int64][]string)
+	for _, t := range targets {
+		byDistrict[t.DistrictID] = append(byDistrict[t.DistrictID], t.TargetDate.Format(time.DateOnly))
+	}
+	for _, dates := range byDistrict {
+		sort.Strings(dates)
+	}
+	return byDistrict
+}
+
+// appLocation is the application timezone, UTC when it can not be loaded
+func appLocation() *time.Location {
+	loc, err := time.LoadLocation(config.App().Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
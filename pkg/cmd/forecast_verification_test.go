@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildForecastSnapshots(t *testing.T) {
+	dhaka := time.FixedZone("", 6*3600)
+	// 00:40 local is still the previous day in UTC
+	issuedAt := time.Date(2024, 5, 2, 0, 40, 0, 0, dhaka)
+	days := map[string]*domain.TravelConditions{
+		"2024-05-04": {Temp2PM: 34, PM25: 60},
+		"2024-05-02": {Temp2PM: 33, PM25: 55},
+	}
+
+	snapshots := buildForecastSnapshots(47, issuedAt, days)
+
+	if assert.Len(t, snapshots, 2) {
+		assert.Equal(t, time.Date(2024, 5, 2, 0, 0, 0, 0, dhaka), snapshots[0].IssuedAt)
+		assert.Equal(t, "2024-05-02", snapshots[0].TargetDate.Format(time.DateOnly))
+		assert.Equal(t, 0, snapshots[0].LeadDays)
+		assert.Equal(t, 33.0, *snapshots[0].Temp2PM)
+		assert.Equal(t, int64(47), snapshots[1].DistrictID)
+		assert.Equal(t, 2, snapshots[1].LeadDays)
+		assert.Equal(t, 60.0, *snapshots[1].PM25)
+	}
+}
+
+func TestGroupForecastTargets(t *testing.T) {
+	day := func(s string) time.Time {
+		t, _ := time.Parse(time.DateOnly, s)
+		return t
+	}
+
+	grouped := groupForecastTargets([]domain.ForecastTarget{
+		{DistrictID: 1, TargetDate: day("2024-05-03")},
+		{DistrictID: 2, TargetDate: day("2024-05-01")},
+		{DistrictID: 1, TargetDate: day("2024-05-01")},
+	})
+
+	assert.Equal(t, map[int64][]string{
+		1: {"2024-05-01", "2024-05-03"},
+		2: {"2024-05-01"},
+	}, grouped)
+}
+
+func TestVerifiableBefore(t *testing.T) {
+	now := time.Date(2024, 5, 10, 20, 30, 0, 0, time.UTC)
+
+	// the archive lags five days, so May 5 is the most recent verifiable day
+	assert.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), verifiableBefore(now))
+	assert.Equal(t, helpers.ArchiveCutoffAt(now), verifiableBefore(now).AddDate(0, 0, -1))
+}
+
+func TestSnapshotSchedule(t *testing.T) {
+	now := time.Date(2024, 5, 10, 20, 5, 0, 0, time.UTC)
+	var s snapshotSchedule
+
+	assert.True(t, s.claim(47, now))
+	assert.False(t, s.claim(47, now.Add(50*time.Minute)), "same hour")
+	assert.True(t, s.claim(36, now), "other district")
+	assert.True(t, s.claim(47, now.Add(time.Hour)))
+
+	s.release(47)
+	assert.True(t, s.claim(47, now.Add(time.Hour)), "released after a failure")
+}
@@ -2,84 +2,256 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
 	"strconv"
+	"strings"
+	"time"
 	"travel_advisor/domain"
 	"travel_advisor/pkg/conn"
+	"travel_advisor/pkg/geo"
+	"travel_advisor/pkg/migrations"
+	"travel_advisor/pkg/seed"
 
 	"github.com/spf13/cobra"
 )
 
-const createDistrictsTable = `
-		CREATE TABLE IF NOT EXISTS districts (
-		id SERIAL PRIMARY KEY,
-		division_id INT NOT NULL,
-		name VARCHAR(100) NOT NULL UNIQUE,
-		bn_name VARCHAR(100),
-		lat DOUBLE PRECISION NOT NULL,
-		long DOUBLE PRECISION NOT NULL,
-		created_at TIMESTAMP DEFAULT NOW(),
-		updated_at TIMESTAMP DEFAULT NOW()
-		);
-`
-const createUser = `CREATE TABLE IF NOT EXISTS users (
-    id SERIAL PRIMARY KEY,
-    name VARCHAR(100) NOT NULL,
-    email VARCHAR(150) NOT NULL UNIQUE,
-    password VARCHAR(255) NOT NULL,
-    created_at TIMESTAMP DEFAULT NOW(),
-    updated_at TIMESTAMP DEFAULT NOW()
-);
-`
+// boundaryNameKeys are the feature properties checked, in order, for the district name
+var boundaryNameKeys = []string{"name", "NAME_2", "ADM2_EN", "shapeName", "district"}
 
 var (
-	districtsMigrationCmd = &cobra.Command{
+	boundariesFile string
+	seedSource     string
+	migrationsDir  string
+
+	migrationCmd = &cobra.Command{
 		Use:   "migration",
-		Short: "Run districts and user migrations",
-		Long:  `Run districts and user migrations`,
-		PreRun: func(cmd *cobra.Command, args []string) {
+		Short: "Run schema migrations and seed districts",
+		Long:  `Run schema migrations and seed districts. Without a subcommand it applies all pending migrations and seeds the districts`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			fmt.Println("--------Database is connecting-------")
-			err := conn.ConnectDefaultDB()
-			if err != nil {
-				fmt.Println("Failed to connect to database:", err)
-				return
+			if err := conn.ConnectDefaultDB(); err != nil {
+				return fmt.Errorf("failed to connect to database: %v", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := MigrateUpCmd(cmd, args); err != nil {
+				return err
 			}
+			return DistrictsMigrationsCmd(cmd, args)
 		},
-		Run: DistrictsMigrationsCmd,
+	}
+
+	migrationUpCmd = &cobra.Command{
+		Use:   "up",
+		Short: "Apply all pending schema migrations",
+		Long:  `Apply all pending schema migrations`,
+		Args:  cobra.NoArgs,
+		RunE:  MigrateUpCmd,
+	}
+
+	migrationDownCmd = &cobra.Command{
+		Use:   "down N",
+		Short: "Revert the last N applied schema migrations",
+		Long:  `Revert the last N applied schema migrations`,
+		Args:  cobra.ExactArgs(1),
+		RunE:  MigrateDownCmd,
+	}
+
+	migrationStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show applied and pending schema migrations",
+		Long:  `Show applied and pending schema migrations`,
+		Args:  cobra.NoArgs,
+		RunE:  MigrateStatusCmd,
+	}
+
+	migrationCreateCmd = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a new pair of up/down migration files",
+		Long:  `Create a new pair of up/down migration files`,
+		Args:  cobra.ExactArgs(1),
+		// creating files does not need a database connection
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+		RunE:              MigrateCreateCmd,
+	}
+
+	migrationSeedCmd = &cobra.Command{
+		Use:   "seed",
+		Short: "Upsert the districts dataset and optional boundaries",
+		Long:  `Upsert the districts dataset and optional boundaries`,
+		Args:  cobra.NoArgs,
+		RunE:  DistrictsMigrationsCmd,
 	}
 )
 
 func init() {
-	rootCmd.AddCommand(districtsMigrationCmd)
+	for _, c := range []*cobra.Command{migrationCmd, migrationSeedCmd} {
+		c.Flags().StringVar(&boundariesFile, "boundaries", "", "local GeoJSON file with district boundary polygons")
+		c.Flags().StringVar(&seedSource, "source", "", "districts JSON file path or URL, defaults to the bundled dataset")
+	}
+	migrationCreateCmd.Flags().StringVar(&migrationsDir, "dir", migrations.DefaultDir, "directory holding the migration files")
+
+	migrationCmd.AddCommand(migrationUpCmd, migrationDownCmd, migrationStatusCmd, migrationCreateCmd, migrationSeedCmd)
+	rootCmd.AddCommand(migrationCmd)
 }
 
-func DistrictsMigrationsCmd(cmd *cobra.Command, args []string) {
-	db := conn.DefaultDB()
-	conn.InitClient()
-	client := conn.GetHTTClient()
+func newMigrator() (*migrations.Migrator, error) {
+	list, err := migrations.Load()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := conn.DefaultDB().DB.DB()
+	if err != nil {
+		return nil, err
+	}
+	return migrations.NewMigrator(sqlDB, list), nil
+}
+
+func MigrateUpCmd(cmd *cobra.Command, args []string) error {
+	m, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	applied, err := m.Up(cmd.Context())
+	for _, mg := range applied {
+		fmt.Printf("Applied %04d_%s\n", mg.Version, mg.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Println("Schema is up to date")
+	}
+	return nil
+}
+
+func MigrateDownCmd(cmd *cobra.Command, args []string) error {
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		return fmt.Errorf("invalid number of migrations to revert: %s", args[0])
+	}
+	m, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	reverted, err := m.Down(cmd.Context(), n)
+	for _, mg := range reverted {
+		fmt.Printf("Reverted %04d_%s\n", mg.Version, mg.Name)
+	}
+	return err
+}
+
+func MigrateStatusCmd(cmd *cobra.Command, args []string) error {
+	m, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	list, err := m.Status(cmd.Context())
+	if err != nil {
+		return err
+	}
+	for _, st := range list {
+		state := "pending"
+		if st.AppliedAt != nil {
+			state = "applied at " + st.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%04d_%-40s %s\n", st.Version, st.Name, state)
+	}
+	return nil
+}
 
-	if res := db.Exec(createDistrictsTable); res.Error != nil {
-		fmt.Println("Failed to create districts table:", res.Error)
-		return
+func MigrateCreateCmd(cmd *cobra.Command, args []string) error {
+	paths, err := migrations.Create(migrationsDir, args[0])
+	if err != nil {
+		return err
+	}
+	for _, p := range paths {
+		fmt.Println("Created", p)
 	}
-	if res := db.Exec(createUser); res.Error != nil {
-		fmt.Println("Failed to create users table:", res.Error)
-		return
+	return nil
+}
+
+func DistrictsMigrationsCmd(cmd *cobra.Command, args []string) error {
+	db := conn.DefaultDB()
+
+	bb, err := loadDistrictSeed(seedSource)
+	if err != nil {
+		return fmt.Errorf("failed to load districts: %v", err)
 	}
 
-	url := "https://raw.githubusercontent.com/strativ-dev/technical-screening-test/main/bd-districts.json"
-	resp, err := client.Get(url)
+	districts, err := seed.ParseDistricts(bb)
 	if err != nil {
-		fmt.Println("Failed to fetch districts JSON:", err)
-		return
+		fmt.Println("Districts validation failed:")
+		fmt.Println(err)
+		return errors.New("districts migration aborted, no rows were written")
+	}
+
+	if err := upsertDistricts(db, districts); err != nil {
+		return err
+	}
+
+	fmt.Printf("Districts migration completed successfully, %d districts upserted\n", len(districts))
+
+	if boundariesFile != "" {
+		if err := importDistrictBoundaries(db, boundariesFile); err != nil {
+			return fmt.Errorf("failed to import district boundaries: %v", err)
+		}
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	var payload domain.DistrictResponse
-	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		fmt.Println("JSON decode failed:", err)
-		return
+// loadDistrictSeed reads the districts dataset from a URL, a local file or the bundled copy
+func loadDistrictSeed(source string) ([]byte, error) {
+	switch {
+	case source == "":
+		return seed.Districts, nil
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		resp, err := conn.GetHTTClient().Get(source)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, source)
+		}
+		return io.ReadAll(resp.Body)
+	default:
+		return os.ReadFile(source)
 	}
+}
+
+// upsertDistricts writes all districts in one transaction, updating rows that
+// already exist. Rows are matched on the unique name first, so a district
+// seeded under another id keeps its id and the rows that reference it, then
+// on the id, so a renamed district is updated in place.
+func upsertDistricts(db *conn.DB, districts []*domain.District) error {
+	update := `
+		UPDATE districts SET
+			division_id = $2,
+			bn_name = $3,
+			lat = $4,
+			long = $5,
+			updated_at = NOW()
+		WHERE name = $1;
+	   `
+	stmt := `
+		INSERT INTO districts (id, division_id, name, bn_name, lat, long)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			division_id = EXCLUDED.division_id,
+			name = EXCLUDED.name,
+			bn_name = EXCLUDED.bn_name,
+			lat = EXCLUDED.lat,
+			long = EXCLUDED.long,
+			updated_at = NOW();
+	   `
+	// ids are inserted explicitly, so move the serial sequence past them
+	syncSequence := `SELECT setval(pg_get_serial_sequence('districts', 'id'), (SELECT MAX(id) FROM districts));`
 
 	tx := db.Begin()
 	defer func() {
@@ -88,29 +260,82 @@ func DistrictsMigrationsCmd(cmd *cobra.Command, args []string) {
 		}
 	}()
 
-	stmt := `
-		INSERT INTO districts (id, division_id, name, bn_name, lat, long)
-		VALUES ($1, $2, $3, $4, $5, $6);
-	   `
+	for _, d := range districts {
+		res := tx.Exec(update, d.Name, d.DivisionID, d.BnName, d.Lat, d.Long)
+		if res.Error != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to update district %s: %v", d.Name, res.Error)
+		}
+		if res.RowsAffected > 0 {
+			continue
+		}
+		res = tx.Exec(stmt, d.ID, d.DivisionID, d.Name, d.BnName, d.Lat, d.Long)
+		if res.Error != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to upsert district %s: %v", d.Name, res.Error)
+		}
+	}
+	if res := tx.Exec(syncSequence); res.Error != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to sync districts id sequence: %v", res.Error)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return nil
+}
+
+// importDistrictBoundaries stores the polygon of every feature whose name matches a district
+func importDistrictBoundaries(db *conn.DB, path string) error {
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
 
-	for _, d := range payload.Districts {
-		id, _ := strconv.Atoi(d.Id)
-		divisionID, _ := strconv.Atoi(d.DivisionID)
-		lat, _ := strconv.ParseFloat(d.Lat, 64)
-		long, _ := strconv.ParseFloat(d.Long, 64)
+	var fc geo.FeatureCollection
+	if err := json.Unmarshal(bb, &fc); err != nil {
+		return fmt.Errorf("invalid GeoJSON: %v", err)
+	}
+
+	stmt := `UPDATE districts SET boundary = $1, updated_at = NOW() WHERE lower(name) = lower($2);`
+
+	tx := db.Begin()
+	var matched int
+	for i, f := range fc.Features {
+		name := strings.TrimSpace(f.StringProperty(boundaryNameKeys...))
+		if name == "" {
+			fmt.Println("Skipping boundary feature without a name at index", i)
+			continue
+		}
+
+		polygons, err := f.Geometry.MultiPolygon()
+		if err != nil {
+			fmt.Println("Skipping boundary feature:", name, err)
+			continue
+		}
+		coords, err := json.Marshal(polygons)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
 
-		res := tx.Exec(stmt, id, divisionID, d.Name, d.BnName, lat, long)
+		res := tx.Exec(stmt, string(coords), name)
 		if res.Error != nil {
-			fmt.Println("Failed to insert/update district:", d.Name, res.Error)
-			return
+			tx.Rollback()
+			return fmt.Errorf("failed to update boundary of %s: %v", name, res.Error)
+		}
+		if res.RowsAffected == 0 {
+			fmt.Println("No district matches boundary feature:", name)
+			continue
 		}
+		matched++
 	}
 
 	if err := tx.Commit().Error; err != nil {
-		fmt.Println("Failed to commit transaction:", err)
-		return
+		return err
 	}
 
-	fmt.Println("Districts migration completed successfully")
-
+	fmt.Printf("Imported %d district boundaries from %s\n", matched, path)
+	return nil
 }
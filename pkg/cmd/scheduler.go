@@ -2,8 +2,8 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
@@ -39,6 +39,8 @@ var (
 				log.Fatal(err)
 			}
 			log.Info("Cache server connected successfully!")
+
+			conn.InitClient()
 		},
 		RunE: scheduler,
 	}
@@ -64,6 +66,11 @@ func scheduler(cmd *cobra.Command, args []string) error {
 			log.Warn("failed to schedule check presigned url status cron:", err)
 		}
 	}(ctx)
+	go func(ctx context.Context) {
+		if err := ScheduleForecastVerification(ctx, cfg, repositories); err != nil {
+			log.Warn("failed to schedule forecast verification cron:", err)
+		}
+	}(ctx)
 
 	// Wait for the shutdown signal
 	<-sigCh
@@ -80,13 +87,18 @@ func scheduler(cmd *cobra.Command, args []string) error {
 }
 
 func ScheduleDistrictCacheRefresh(ctx context.Context, cfg config.SchedulerCfg, repositories dependencies.RepositoryInterfaces) error {
+	agg, err := helpers.NewAggregation(config.Aggregation())
+	if err != nil {
+		return err
+	}
+
+	var snapshots snapshotSchedule
 	s := cron.New()
-	_, err := s.AddFunc(cfg.CronExpr, func() {
+	_, err = s.AddFunc(cfg.CronExpr, func() {
 		districts, err := repositories.Districts.List(ctx, &domain.DistrictCriteria{})
 		if err != nil {
 			log.Warn("failed to get all districts")
 		}
-		conn.InitClient()
 		client := conn.GetHTTClient()
 		wg := sync.WaitGroup{}
 		wg.Add(len(districts))
@@ -96,37 +108,27 @@ func ScheduleDistrictCacheRefresh(ctx context.Context, cfg config.SchedulerCfg,
 			go func() {
 				defer wg.Done()
 
-				log.Info("Starting district " + d.Name)
+				log.Info("Starting district %s", d.Name)
 
-				temp, err := helpers.FetchAvgTempAt2PM(ctx, client, d.Lat, d.Long, nil)
-				if err != nil {
-					log.Println(err)
-					log.Warn("temp fetch failed ", d.Name)
-					return
-				}
+				// the observations are the durable history, they are stored
+				// whether or not the cache refresh succeeds
+				storeObservations(ctx, client, repositories, d)
 
-				pm25, err := helpers.FetchAvgPM25(ctx, client, d.Lat, d.Long, nil)
-				if err != nil {
-					log.Println(err)
-					log.Warn("air quality fetch failed", d.Name)
-					return
-				}
-				districtCache := domain.DistrictCache{
-					Name:       d.Name,
-					AvgTemp2PM: temp,
-					AvgPM25:    pm25,
-				}
-				bytes, err := json.Marshal(districtCache)
+				entry, err := helpers.FetchDistrictCache(ctx, client, d, agg, time.Now())
 				if err != nil {
-					log.Warn("marshal failed", err)
+					log.Warn("district cache fetch failed ", d.Name, err)
 					return
 				}
-
-				err = repositories.Cacher.Set(ctx, d.Name, bytes, time.Hour*24)
-				if err != nil {
+				if err := helpers.StoreDistrictCache(ctx, repositories.Cacher, entry); err != nil {
 					log.Warn("failed to set cache", d.Name, err)
 				}
 
+				if now := time.Now(); snapshots.claim(d.ID, now) {
+					if err := snapshotForecasts(ctx, repositories, d, entry, now); err != nil {
+						log.Warn("forecast snapshot failed ", d.Name, err)
+						snapshots.release(d.ID)
+					}
+				}
 			}()
 		}
 
@@ -142,3 +144,18 @@ func ScheduleDistrictCacheRefresh(ctx context.Context, cfg config.SchedulerCfg,
 
 	return nil
 }
+
+// storeObservations upserts the observations of the district over the past day
+func storeObservations(ctx context.Context, client *http.Client, repositories dependencies.RepositoryInterfaces, d *domain.District) {
+	observations, err := helpers.FetchObservations(ctx, client, d.Lat, d.Long, 1)
+	if err != nil {
+		log.Warn("observations fetch failed ", d.Name, err)
+		return
+	}
+	for _, o := range observations {
+		o.DistrictID = d.ID
+	}
+	if err := repositories.Observations.Upsert(ctx, observations); err != nil {
+		log.Warn("failed to store observations ", d.Name, err)
+	}
+}
@@ -18,7 +18,13 @@ import (
 	userReposiotry "travel_advisor/user/repository"
 	userUsecase "travel_advisor/user/usecase"
 
+	districtHandler "travel_advisor/districts/delivery/http"
 	districtRepository "travel_advisor/districts/repository"
+	districtUsecase "travel_advisor/districts/usecase"
+
+	forecastHandler "travel_advisor/forecasts/delivery/http"
+	forecastRepository "travel_advisor/forecasts/repository"
+	forecastUsecase "travel_advisor/forecasts/usecase"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -44,6 +50,8 @@ var (
 			}
 			log.Info("Cache server connected successfully!")
 
+			conn.InitClient()
+
 		},
 		Run: serve,
 	}
@@ -90,12 +98,19 @@ func buildHTTP(cmd *cobra.Command, args []string, httpCfg config.HttpApplication
 	cacher := conn.DefaultCache()
 
 	dis := districtRepository.NewDistrictPostgreSQL(db)
-	tc := travelUsecase.NewTravelUsecase(cacher, dis)
+	obs := districtRepository.NewObservationPostgreSQL(db)
+	dc := districtUsecase.NewDistrictUsecase(dis, obs)
+	normals := districtRepository.NewClimateNormalPostgreSQL(db)
+	tc := travelUsecase.NewTravelUsecase(cacher, dis, normals)
 	us := userReposiotry.NewUserPostgreSQL(db)
 	uc := userUsecase.NewUserUsecase(us)
+	snapshots := forecastRepository.NewForecastSnapshotPostgreSQL(db)
+	fc := forecastUsecase.NewForecastUsecase(dis, snapshots)
 
-	travelHandler.NewTravelHandler(r, tc)
+	districtHandler.NewDistrictHandler(r, dc)
+	travelHandler.NewTravelHandler(r, tc, dc)
 	userHandler.NewUserHandler(r, uc)
+	forecastHandler.NewForecastHandler(r, fc)
 
 	httpPort := fmt.Sprintf(":%d", httpCfg.HTTPPort)
 	log.Println("HTTP Listening on port", httpPort)
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+type AggregationCfg struct {
+	// HorizonDays is how many forecast days the district metrics cover
+	HorizonDays int `json:"horizon_days"`
+	// HourStart and HourEnd bound, inclusively, the local hours the weather
+	// metrics are sampled at. PM2.5 always uses every hour, as its AQI is a
+	// 24 hour mean.
+	HourStart int `json:"hour_start"`
+	HourEnd   int `json:"hour_end"`
+	// Statistic reduces the samples to the reported value: mean, median, p90 or max
+	Statistic string `json:"statistic"`
+}
+
+var aggregation AggregationCfg
+
+// Aggregation contains the district metrics aggregation configuration
+func Aggregation() AggregationCfg {
+	return aggregation
+}
+
+func loadAggregation() {
+	aggregation = AggregationCfg{
+		HorizonDays: viper.GetInt("aggregation.horizon_days"),
+		HourStart:   viper.GetInt("aggregation.hour_start"),
+		HourEnd:     viper.GetInt("aggregation.hour_end"),
+		Statistic:   viper.GetString("aggregation.statistic"),
+	}
+}
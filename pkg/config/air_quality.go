@@ -0,0 +1,23 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+type AirQualityCfg struct {
+	// AQIStandard is the breakpoint table of the reported AQI, us_epa or bd_doe
+	AQIStandard string `json:"aqi_standard"`
+}
+
+var airQuality AirQualityCfg
+
+// AirQuality contains the air quality index configuration
+func AirQuality() AirQualityCfg {
+	return airQuality
+}
+
+func loadAirQuality() {
+	airQuality = AirQualityCfg{
+		AQIStandard: viper.GetString("air_quality.aqi_standard"),
+	}
+}
@@ -19,6 +19,8 @@ type HttpApplication struct {
 
 type AppConfig struct {
 	JwtSecret string
+	// Timezone is the IANA zone used to cut days for the districts
+	Timezone string
 }
 
 var http_app HttpApplication
@@ -35,6 +37,7 @@ func App() AppConfig {
 func loadApp() {
 	appConfig = AppConfig{
 		JwtSecret: viper.GetString("app.jwt_secret"),
+		Timezone:  viper.GetString("app.timezone"),
 	}
 
 	http_app = HttpApplication{
@@ -27,4 +27,10 @@ func initConfig() {
 	loadScheduler()
 	loadRedis()
 	loadDatabase()
+	loadRecommendation()
+	loadMeetup()
+	loadAirQuality()
+	loadAggregation()
+	loadEnsemble()
+	loadHTTPClient()
 }
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInit_RepositoryConfig loads the config.yml shipped at the repository root
+func TestInit_RepositoryConfig(t *testing.T) {
+	viper.AddConfigPath("../..")
+
+	if !assert.NoError(t, Init("")) {
+		return
+	}
+
+	assert.Equal(t, 8080, HttpApp().HTTPPort)
+	assert.Equal(t, 30*time.Second, HttpApp().ReadTimeout)
+	assert.NotEmpty(t, App().JwtSecret)
+	assert.Equal(t, "Asia/Dhaka", App().Timezone)
+	assert.NotEmpty(t, Scheduler().CronExpr)
+	assert.Equal(t, HTTPClientCfg{
+		CallTimeout:         15 * time.Second,
+		MaxRetries:          2,
+		RetryBackoff:        250 * time.Millisecond,
+		MaxRetryBackoff:     2 * time.Second,
+		BreakerThreshold:    5,
+		BreakerCooldown:     30 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+	}, HTTPClient())
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+type EnsembleCfg struct {
+	// Models are the Open-Meteo weather models the forecast confidence is
+	// derived from, at least two
+	Models []string `json:"models"`
+	// MaxTempSpread (°C) and MaxPrecipitationSpread (mm) are the model
+	// standard deviations at which the models fully disagree
+	MaxTempSpread          float64 `json:"max_temp_spread"`
+	MaxPrecipitationSpread float64 `json:"max_precipitation_spread"`
+	// LeadDecayPerDay is the confidence lost for every day beyond tomorrow
+	LeadDecayPerDay float64 `json:"lead_decay_per_day"`
+}
+
+var ensemble EnsembleCfg
+
+// Ensemble contains the multi-model forecast confidence configuration
+func Ensemble() EnsembleCfg {
+	return ensemble
+}
+
+func loadEnsemble() {
+	ensemble = EnsembleCfg{
+		Models:                 viper.GetStringSlice("ensemble.models"),
+		MaxTempSpread:          viper.GetFloat64("ensemble.max_temp_spread"),
+		MaxPrecipitationSpread: viper.GetFloat64("ensemble.max_precipitation_spread"),
+		LeadDecayPerDay:        viper.GetFloat64("ensemble.lead_decay_per_day"),
+	}
+}
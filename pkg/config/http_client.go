@@ -0,0 +1,44 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// HTTPClientCfg tunes the shared client of the outbound provider calls.
+// Unset values fall back to the client defaults.
+type HTTPClientCfg struct {
+	CallTimeout      time.Duration
+	MaxRetries       int
+	RetryBackoff     time.Duration
+	MaxRetryBackoff  time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+}
+
+var httpClient HTTPClientCfg
+
+// HTTPClient contains the outbound http client configuration
+func HTTPClient() HTTPClientCfg {
+	return httpClient
+}
+
+func loadHTTPClient() {
+	httpClient = HTTPClientCfg{
+		CallTimeout:      viper.GetDuration("http_client.call_timeout") * time.Second,
+		MaxRetries:       viper.GetInt("http_client.max_retries"),
+		RetryBackoff:     viper.GetDuration("http_client.retry_backoff") * time.Millisecond,
+		MaxRetryBackoff:  viper.GetDuration("http_client.max_retry_backoff") * time.Millisecond,
+		BreakerThreshold: viper.GetInt("http_client.breaker_threshold"),
+		BreakerCooldown:  viper.GetDuration("http_client.breaker_cooldown") * time.Second,
+
+		MaxIdleConns:        viper.GetInt("http_client.max_idle_conns"),
+		MaxIdleConnsPerHost: viper.GetInt("http_client.max_idle_conns_per_host"),
+		IdleConnTimeout:     viper.GetDuration("http_client.idle_conn_timeout") * time.Second,
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+type MeetupCfg struct {
+	// DistanceWeight is the share of the meetup score given to travel distance,
+	// the rest goes to the destination comfort
+	DistanceWeight float64 `json:"distance_weight"`
+	// MaxDistanceKm is the per traveler distance that scores zero
+	MaxDistanceKm float64 `json:"max_distance_km"`
+	// Candidates is how many of the closest districts are forecast and scored
+	Candidates int `json:"candidates"`
+	MaxOrigins int `json:"max_origins"`
+	// Results caps the suggested meeting points
+	Results int `json:"results"`
+}
+
+var meetup MeetupCfg
+
+// Meetup contains the group meeting point configuration
+func Meetup() MeetupCfg {
+	return meetup
+}
+
+func loadMeetup() {
+	meetup = MeetupCfg{
+		DistanceWeight: viper.GetFloat64("meetup.distance_weight"),
+		MaxDistanceKm:  viper.GetFloat64("meetup.max_distance_km"),
+		Candidates:     viper.GetInt("meetup.candidates"),
+		MaxOrigins:     viper.GetInt("meetup.max_origins"),
+		Results:        viper.GetInt("meetup.results"),
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// ScoringWeights is the relative weight of each factor in the travel score
+type ScoringWeights struct {
+	Temperature   float64 `json:"temperature"`
+	PM25          float64 `json:"pm25"`
+	HeatIndex     float64 `json:"heat_index"`
+	Precipitation float64 `json:"precipitation"`
+	Wind          float64 `json:"wind"`
+	Rain          float64 `json:"rain"`
+	// UV is optional, the sun exposure only counts when it is weighted
+	UV float64 `json:"uv"`
+}
+
+type RecommendationCfg struct {
+	Weights ScoringWeights `json:"weights"`
+	// RecommendedScore and AcceptableScore are the lowest scores of each verdict tier
+	RecommendedScore float64 `json:"recommended_score"`
+	AcceptableScore  float64 `json:"acceptable_score"`
+	// MaxCompareDestinations caps the candidates of a single compare request
+	MaxCompareDestinations int `json:"max_compare_destinations"`
+	// MaxItineraryStops caps the must visit and optional districts of an itinerary
+	MaxItineraryStops int `json:"max_itinerary_stops"`
+	// WindowHours is the length of the recommended departure and arrival
+	// windows, searched between TravelHoursStart and TravelHoursEnd (exclusive)
+	WindowHours      int `json:"window_hours"`
+	TravelHoursStart int `json:"travel_hours_start"`
+	TravelHoursEnd   int `json:"travel_hours_end"`
+	// AlternativesRadiusKm bounds the distance of suggested alternatives from
+	// the destination or the origin, at most MaxAlternatives are suggested
+	AlternativesRadiusKm float64 `json:"alternatives_radius_km"`
+	MaxAlternatives      int     `json:"max_alternatives"`
+}
+
+var recommendation RecommendationCfg
+
+// Recommendation contains the travel recommendation scoring configuration
+func Recommendation() RecommendationCfg {
+	return recommendation
+}
+
+func loadRecommendation() {
+	recommendation = RecommendationCfg{
+		Weights: ScoringWeights{
+			Temperature:   viper.GetFloat64("recommendation.weights.temperature"),
+			PM25:          viper.GetFloat64("recommendation.weights.pm25"),
+			HeatIndex:     viper.GetFloat64("recommendation.weights.heat_index"),
+			Precipitation: viper.GetFloat64("recommendation.weights.precipitation"),
+			Wind:          viper.GetFloat64("recommendation.weights.wind"),
+			Rain:          viper.GetFloat64("recommendation.weights.rain"),
+			UV:            viper.GetFloat64("recommendation.weights.uv"),
+		},
+		RecommendedScore: viper.GetFloat64("recommendation.recommended_score"),
+		AcceptableScore:  viper.GetFloat64("recommendation.acceptable_score"),
+
+		MaxCompareDestinations: viper.GetInt("recommendation.max_compare_destinations"),
+		MaxItineraryStops:      viper.GetInt("recommendation.max_itinerary_stops"),
+
+		WindowHours:      viper.GetInt("recommendation.window_hours"),
+		TravelHoursStart: viper.GetInt("recommendation.travel_hours_start"),
+		TravelHoursEnd:   viper.GetInt("recommendation.travel_hours_end"),
+
+		AlternativesRadiusKm: viper.GetFloat64("recommendation.alternatives_radius_km"),
+		MaxAlternatives:      viper.GetInt("recommendation.max_alternatives"),
+	}
+}
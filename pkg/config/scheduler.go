@@ -6,6 +6,10 @@ import (
 
 type SchedulerCfg struct {
 	CronExpr string `json:"cron_expr"`
+	// VerifyCronExpr schedules the verification of past forecasts against observations
+	VerifyCronExpr string `json:"verify_cron_expr"`
+	// StaleAfterMinutes is the age at which a read refreshes a district cache entry
+	StaleAfterMinutes int `json:"stale_after_minutes"`
 }
 
 var scheduler SchedulerCfg
@@ -17,6 +21,9 @@ func Scheduler() SchedulerCfg {
 
 func loadScheduler() {
 	scheduler = SchedulerCfg{
-		CronExpr: viper.GetString("scheduler.cron_expr"),
+		CronExpr:       viper.GetString("scheduler.cron_expr"),
+		VerifyCronExpr: viper.GetString("scheduler.verify_cron_expr"),
+
+		StaleAfterMinutes: viper.GetInt("scheduler.stale_after_minutes"),
 	}
 }
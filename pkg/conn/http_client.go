@@ -2,19 +2,40 @@ package conn
 
 import (
 	"net/http"
-	"time"
+	"sync"
+
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/httpclient"
 )
 
-var client *http.Client
+var (
+	client     *http.Client
+	clientOnce sync.Once
+)
 
-// GetHTTClient return a http client
+// GetHTTClient return the shared outbound http client, built on first use
 func GetHTTClient() *http.Client {
+	InitClient()
 	return client
 }
 
-// InitClient init the http client
+// InitClient builds the shared http client from the configuration. Only the
+// first call has an effect, so the pooled connections and the circuit
+// breakers are shared by every caller.
 func InitClient() {
-	client = &http.Client{
-		Timeout: 60 * time.Second,
-	}
+	clientOnce.Do(func() {
+		cfg := config.HTTPClient()
+		client = httpclient.New(httpclient.Config{
+			CallTimeout:      cfg.CallTimeout,
+			MaxRetries:       cfg.MaxRetries,
+			RetryBackoff:     cfg.RetryBackoff,
+			MaxRetryBackoff:  cfg.MaxRetryBackoff,
+			BreakerThreshold: cfg.BreakerThreshold,
+			BreakerCooldown:  cfg.BreakerCooldown,
+
+			MaxIdleConns:        cfg.MaxIdleConns,
+			MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+			IdleConnTimeout:     cfg.IdleConnTimeout,
+		})
+	})
 }
@@ -0,0 +1,76 @@
+package geo
+
+import "math"
+
+const earthRadiusKm = 6371.0
+
+// Position is a GeoJSON position, ordered as [long, lat]
+type Position [2]float64
+
+// Ring is a closed linear ring of positions
+type Ring []Position
+
+// Polygon is an outer ring followed by zero or more holes
+type Polygon []Ring
+
+// MultiPolygon is a set of polygons making up a single area
+type MultiPolygon []Polygon
+
+// Contains reports whether the point lies inside the outer ring and outside every hole
+func (p Polygon) Contains(lat, long float64) bool {
+	if len(p) == 0 || !p[0].contains(lat, long) {
+		return false
+	}
+	for _, hole := range p[1:] {
+		if hole.contains(lat, long) {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains reports whether the point lies inside any of the polygons
+func (m MultiPolygon) Contains(lat, long float64) bool {
+	for _, p := range m {
+		if p.Contains(lat, long) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty reports whether the multipolygon has no usable outer ring
+func (m MultiPolygon) IsEmpty() bool {
+	for _, p := range m {
+		if len(p) > 0 && len(p[0]) >= 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// contains runs an even-odd ray casting test against the ring
+func (r Ring) contains(lat, long float64) bool {
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		xi, yi := r[i][0], r[i][1]
+		xj, yj := r[j][0], r[j][1]
+		if (yi > lat) != (yj > lat) && long < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Distance returns the great-circle distance between two points in kilometers
+func Distance(lat1, long1, lat2, long2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLong := radians(long2 - long1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,56 @@
+package geo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiPolygon_Contains(t *testing.T) {
+	square := Polygon{
+		Ring{{90, 23}, {91, 23}, {91, 24}, {90, 24}, {90, 23}},
+		Ring{{90.4, 23.4}, {90.6, 23.4}, {90.6, 23.6}, {90.4, 23.6}, {90.4, 23.4}},
+	}
+	island := Polygon{
+		Ring{{92, 22}, {92.5, 22}, {92.5, 22.5}, {92, 22}},
+	}
+	m := MultiPolygon{square, island}
+
+	tests := []struct {
+		name      string
+		lat, long float64
+		expected  bool
+	}{
+		{name: "inside outer ring", lat: 23.2, long: 90.2, expected: true},
+		{name: "inside hole", lat: 23.5, long: 90.5, expected: false},
+		{name: "inside second polygon", lat: 22.1, long: 92.4, expected: true},
+		{name: "outside everything", lat: 25, long: 89, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, m.Contains(tt.lat, tt.long))
+		})
+	}
+}
+
+func TestGeometry_MultiPolygon(t *testing.T) {
+	var g Geometry
+	err := json.Unmarshal([]byte(`{"type":"Polygon","coordinates":[[[90,23],[91,23],[91,24],[90,23]]]}`), &g)
+	assert.NoError(t, err)
+
+	m, err := g.MultiPolygon()
+	assert.NoError(t, err)
+	assert.Len(t, m, 1)
+	assert.False(t, m.IsEmpty())
+
+	_, err = (&Geometry{Type: TypePoint}).MultiPolygon()
+	assert.Error(t, err)
+}
+
+func TestDistance(t *testing.T) {
+	// Dhaka to Sylhet is roughly 195 km as the crow flies
+	d := Distance(23.7104, 90.4074, 24.8949, 91.8687)
+	assert.InDelta(t, 195, d, 5)
+}
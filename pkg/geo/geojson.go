@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	TypeFeature           = "Feature"
+	TypeFeatureCollection = "FeatureCollection"
+	TypePoint             = "Point"
+	TypePolygon           = "Polygon"
+	TypeMultiPolygon      = "MultiPolygon"
+)
+
+// Geometry is a GeoJSON geometry object
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// Feature is a GeoJSON feature
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         interface{}            `json:"id,omitempty"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// FeatureCollection is a GeoJSON feature collection
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// NewFeatureCollection returns an empty feature collection ready to be filled
+func NewFeatureCollection() *FeatureCollection {
+	return &FeatureCollection{
+		Type:     TypeFeatureCollection,
+		Features: make([]Feature, 0),
+	}
+}
+
+// NewPointGeometry builds a Point geometry
+func NewPointGeometry(lat, long float64) *Geometry {
+	bb, _ := json.Marshal(Position{long, lat})
+	return &Geometry{Type: TypePoint, Coordinates: bb}
+}
+
+// NewMultiPolygonGeometry builds a MultiPolygon geometry
+func NewMultiPolygonGeometry(m MultiPolygon) *Geometry {
+	bb, _ := json.Marshal(m)
+	return &Geometry{Type: TypeMultiPolygon, Coordinates: bb}
+}
+
+// MultiPolygon decodes a Polygon or MultiPolygon geometry
+func (g *Geometry) MultiPolygon() (MultiPolygon, error) {
+	if g == nil {
+		return nil, fmt.Errorf("geo: missing geometry")
+	}
+	switch g.Type {
+	case TypePolygon:
+		var p Polygon
+		if err := json.Unmarshal(g.Coordinates, &p); err != nil {
+			return nil, fmt.Errorf("geo: invalid polygon: %v", err)
+		}
+		return MultiPolygon{p}, nil
+	case TypeMultiPolygon:
+		var m MultiPolygon
+		if err := json.Unmarshal(g.Coordinates, &m); err != nil {
+			return nil, fmt.Errorf("geo: invalid multipolygon: %v", err)
+		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("geo: unsupported geometry type %q", g.Type)
+	}
+}
+
+// StringProperty returns the first non-empty string property among keys
+func (f Feature) StringProperty(keys ...string) string {
+	for _, k := range keys {
+		if v, ok := f.Properties[k].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	b := NewBreaker(2, 30*time.Second)
+
+	b.Failure(now)
+	assert.True(t, b.Allow(now), "one failure stays closed")
+	b.Failure(now)
+	assert.False(t, b.Allow(now.Add(10*time.Second)), "open during the cooldown")
+
+	assert.True(t, b.Allow(now.Add(30*time.Second)), "a probe after the cooldown")
+	assert.False(t, b.Allow(now.Add(31*time.Second)), "only one probe at a time")
+
+	b.Failure(now.Add(32 * time.Second))
+	assert.False(t, b.Allow(now.Add(40*time.Second)), "a failed probe reopens")
+
+	assert.True(t, b.Allow(now.Add(62*time.Second)))
+	b.Success()
+	assert.True(t, b.Allow(now.Add(63*time.Second)))
+	assert.True(t, b.Allow(now.Add(63*time.Second)), "closed after a successful probe")
+}
+
+func TestBreaker_AbandonedProbe(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	b := NewBreaker(1, time.Minute)
+
+	b.Failure(now)
+	assert.True(t, b.Allow(now.Add(time.Minute)))
+	b.Abandon()
+
+	assert.True(t, b.Allow(now.Add(time.Minute+time.Second)), "the next call probes again")
+}
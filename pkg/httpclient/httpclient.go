@@ -0,0 +1,231 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling a host whose breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Config tunes the outbound client. Zero fields take the DefaultConfig
+// value, a negative MaxRetries disables retries.
+type Config struct {
+	// CallTimeout bounds every attempt, the deadline of the request
+	// context still applies when it is sooner
+	CallTimeout time.Duration
+	// MaxRetries is how often a failed GET is retried, waiting an
+	// exponentially growing RetryBackoff capped at MaxRetryBackoff
+	MaxRetries      int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	// BreakerThreshold consecutive failures open the breaker of a host
+	// for BreakerCooldown
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	DialTimeout         time.Duration
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+}
+
+var DefaultConfig = Config{
+	CallTimeout:     15 * time.Second,
+	MaxRetries:      2,
+	RetryBackoff:    250 * time.Millisecond,
+	MaxRetryBackoff: 2 * time.Second,
+
+	BreakerThreshold: 5,
+	BreakerCooldown:  30 * time.Second,
+
+	DialTimeout:         5 * time.Second,
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 16,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+func (c Config) withDefaults() Config {
+	d := DefaultConfig
+	if c.CallTimeout <= 0 {
+		c.CallTimeout = d.CallTimeout
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = d.MaxRetries
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = d.RetryBackoff
+	}
+	if c.MaxRetryBackoff < c.RetryBackoff {
+		c.MaxRetryBackoff = max(d.MaxRetryBackoff, c.RetryBackoff)
+	}
+	if c.BreakerThreshold <= 0 {
+		c.BreakerThreshold = d.BreakerThreshold
+	}
+	if c.BreakerCooldown <= 0 {
+		c.BreakerCooldown = d.BreakerCooldown
+	}
+	if c.DialTimeout <= 0 {
+		c.DialTimeout = d.DialTimeout
+	}
+	if c.MaxIdleConns <= 0 {
+		c.MaxIdleConns = d.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost <= 0 {
+		c.MaxIdleConnsPerHost = d.MaxIdleConnsPerHost
+	}
+	if c.IdleConnTimeout <= 0 {
+		c.IdleConnTimeout = d.IdleConnTimeout
+	}
+	return c
+}
+
+// New returns a client with a pooled transport, per host circuit breakers
+// and retries. It has no overall timeout, every attempt gets its own deadline.
+func New(cfg Config) *http.Client {
+	cfg = cfg.withDefaults()
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.DialContext = (&net.Dialer{Timeout: cfg.DialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	base.MaxIdleConns = cfg.MaxIdleConns
+	base.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	base.IdleConnTimeout = cfg.IdleConnTimeout
+
+	return &http.Client{Transport: NewTransport(cfg, base)}
+}
+
+// Transport wraps a base transport with the breakers and the retry policy
+type Transport struct {
+	cfg  Config
+	base http.RoundTripper
+	now  func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+func NewTransport(cfg Config, base http.RoundTripper) *Transport {
+	return &Transport{
+		cfg:      cfg.withDefaults(),
+		base:     base,
+		now:      time.Now,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+func (t *Transport) breaker(host string) *Breaker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b, ok := t.breakers[host]
+	if !ok {
+		b = NewBreaker(t.cfg.BreakerThreshold, t.cfg.BreakerCooldown)
+		t.breakers[host] = b
+	}
+	return b
+}
+
+// RoundTrip sends the request, retrying GETs without a body on network
+// errors, timeouts, 429 and 5xx answers. A retry is skipped when the
+// request context would expire during the backoff.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	b := t.breaker(req.URL.Host)
+
+	retries := 0
+	if (req.Method == http.MethodGet || req.Method == http.MethodHead) && (req.Body == nil || req.Body == http.NoBody) {
+		retries = max(t.cfg.MaxRetries, 0)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if !b.Allow(t.now()) {
+			return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, req.URL.Host)
+		}
+
+		resp, err := t.attempt(req)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			b.Abandon()
+			return nil, err
+		case err == nil && !retryable(resp.StatusCode):
+			b.Success()
+			return resp, nil
+		}
+		b.Failure(t.now())
+
+		if attempt >= retries {
+			return resp, err
+		}
+		wait := t.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt sends one try under its own deadline, released when the body is closed
+func (t *Transport) attempt(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.cfg.CallTimeout)
+	resp, err := t.base.RoundTrip(req.Clone(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// backoff doubles RetryBackoff with every attempt, with jitter, up to
+// MaxRetryBackoff. A Retry-After of the host is honoured within that cap.
+func (t *Transport) backoff(attempt int, resp *http.Response) time.Duration {
+	wait := min(t.cfg.RetryBackoff<<attempt, t.cfg.MaxRetryBackoff)
+	wait = wait/2 + rand.N(wait/2+1)
+
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			wait = max(wait, min(time.Duration(secs)*time.Second, t.cfg.MaxRetryBackoff))
+		}
+	}
+	return wait
+}
+
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,113 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testConfig = Config{
+	CallTimeout:      time.Second,
+	MaxRetries:       2,
+	RetryBackoff:     time.Millisecond,
+	MaxRetryBackoff:  5 * time.Millisecond,
+	BreakerThreshold: 3,
+	BreakerCooldown:  time.Minute,
+}
+
+// statusServer answers with the statuses in order, repeating the last one
+func statusServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		w.WriteHeader(statuses[min(n, len(statuses))-1])
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestClient_RetriesGets(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		statuses       []int
+		expectedStatus int
+		expectedCalls  int32
+	}{
+		{name: "Recovers after server errors", method: http.MethodGet, statuses: []int{503, 502, 200}, expectedStatus: 200, expectedCalls: 3},
+		{name: "Gives up after the retries", method: http.MethodGet, statuses: []int{500}, expectedStatus: 500, expectedCalls: 3},
+		{name: "Client errors are not retried", method: http.MethodGet, statuses: []int{400}, expectedStatus: 400, expectedCalls: 1},
+		{name: "Posts are not retried", method: http.MethodPost, statuses: []int{503, 200}, expectedStatus: 503, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := statusServer(t, tt.statuses...)
+			req, _ := http.NewRequest(tt.method, srv.URL, nil)
+
+			resp, err := New(testConfig).Do(req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestClient_BreakerOpensPerHost(t *testing.T) {
+	failing, calls := statusServer(t, 503)
+	healthy, _ := statusServer(t, 200)
+	client := New(Config{MaxRetries: -1, BreakerThreshold: 2, BreakerCooldown: time.Minute})
+
+	for range 2 {
+		resp, err := client.Get(failing.URL)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	_, err := client.Get(failing.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load(), "an open breaker does not call the host")
+
+	resp, err := client.Get(healthy.URL)
+	assert.NoError(t, err, "other hosts are unaffected")
+	resp.Body.Close()
+}
+
+func TestClient_CallTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(srv.Close)
+	client := New(Config{CallTimeout: 20 * time.Millisecond, MaxRetries: -1})
+
+	start := time.Now()
+	_, err := client.Get(srv.URL)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestClient_StopsWhenTheRequestIsCancelled(t *testing.T) {
+	srv, calls := statusServer(t, 503)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	client := New(Config{RetryBackoff: 50 * time.Millisecond, MaxRetryBackoff: 50 * time.Millisecond, MaxRetries: 5})
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+
+	assert.LessOrEqual(t, calls.Load(), int32(1), "no retry outlives the request deadline")
+}
@@ -0,0 +1,118 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultDir is where `migration create` writes new files, relative to the repository root
+const DefaultDir = "pkg/migrations/sql"
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// fileNamePattern matches 0001_create_districts.up.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a single versioned schema change
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load returns the embedded migrations ordered by version
+func Load() ([]Migration, error) {
+	sub, err := fs.Sub(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return LoadFS(sub)
+}
+
+// LoadFS reads migrations from the root of fsys. Every version needs both an
+// up and a down file.
+func LoadFS(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		m := fileNamePattern.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("migrations: unexpected file name %s", e.Name())
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		bb, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mg, ok := byVersion[version]
+		if !ok {
+			mg = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mg
+		}
+		if mg.Name != m[2] {
+			return nil, fmt.Errorf("migrations: version %d has conflicting names %s and %s", version, mg.Name, m[2])
+		}
+		if m[3] == "up" {
+			mg.Up = string(bb)
+		} else {
+			mg.Down = string(bb)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, mg := range byVersion {
+		if strings.TrimSpace(mg.Up) == "" || strings.TrimSpace(mg.Down) == "" {
+			return nil, fmt.Errorf("migrations: version %d (%s) needs both up and down files", mg.Version, mg.Name)
+		}
+		list = append(list, *mg)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+	return list, nil
+}
+
+// Create writes an empty up/down pair in dir using the next free version
+func Create(dir, name string) ([]string, error) {
+	name = strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, fmt.Errorf("migrations: invalid name")
+	}
+
+	existing, err := LoadFS(os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+	var next int64 = 1
+	if len(existing) > 0 {
+		next = existing[len(existing)-1].Version + 1
+	}
+
+	paths := make([]string, 0, 2)
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%04d_%s.%s.sql", next, name, direction))
+		content := fmt.Sprintf("-- %04d %s (%s)\n", next, name, direction)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
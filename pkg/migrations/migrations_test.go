@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad_Embedded(t *testing.T) {
+	list, err := Load()
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, list)
+	for i, mg := range list {
+		assert.Equal(t, int64(i+1), mg.Version, "versions must be contiguous")
+		assert.NotEmpty(t, mg.Up)
+		assert.NotEmpty(t, mg.Down)
+	}
+}
+
+func TestLoadFS_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		fsys          fstest.MapFS
+		expectedError string
+	}{
+		{
+			name: "Error - Missing down file",
+			fsys: fstest.MapFS{
+				"0001_init.up.sql": {Data: []byte("SELECT 1;")},
+			},
+			expectedError: "needs both up and down files",
+		},
+		{
+			name: "Error - Unexpected file name",
+			fsys: fstest.MapFS{
+				"init.sql": {Data: []byte("SELECT 1;")},
+			},
+			expectedError: "unexpected file name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFS(tt.fsys)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "0001_init.up.sql"), []byte("SELECT 1;"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "0001_init.down.sql"), []byte("SELECT 1;"), 0o644))
+
+	paths, err := Create(dir, "Add Observations")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "0002_add_observations.up.sql"),
+		filepath.Join(dir, "0002_add_observations.down.sql"),
+	}, paths)
+}
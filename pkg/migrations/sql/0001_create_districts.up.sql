@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS districts (
+    id SERIAL PRIMARY KEY,
+    division_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    bn_name VARCHAR(100),
+    lat DOUBLE PRECISION NOT NULL,
+    long DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
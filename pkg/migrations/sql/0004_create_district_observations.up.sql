@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS district_observations (
+    district_id INT NOT NULL REFERENCES districts(id) ON DELETE CASCADE,
+    observed_at TIMESTAMPTZ NOT NULL,
+    temperature_2m DOUBLE PRECISION,
+    relative_humidity_2m DOUBLE PRECISION,
+    precipitation DOUBLE PRECISION,
+    wind_speed_10m DOUBLE PRECISION,
+    pm2_5 DOUBLE PRECISION,
+    pm10 DOUBLE PRECISION,
+    PRIMARY KEY (district_id, observed_at)
+);
+
+CREATE INDEX IF NOT EXISTS district_observations_observed_at_idx ON district_observations (observed_at);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS backfill_progress (
+    district_id INT NOT NULL REFERENCES districts(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    completed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (district_id, day)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS district_climate_normals (
+    district_id INT NOT NULL REFERENCES districts(id) ON DELETE CASCADE,
+    day_of_year SMALLINT NOT NULL CHECK (day_of_year BETWEEN 1 AND 366),
+    temp_2pm_mean DOUBLE PRECISION,
+    temp_2pm_stddev DOUBLE PRECISION,
+    pm25_mean DOUBLE PRECISION,
+    pm25_stddev DOUBLE PRECISION,
+    sample_days INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (district_id, day_of_year)
+);
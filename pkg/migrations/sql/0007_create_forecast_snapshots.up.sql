@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS forecast_snapshots (
+    district_id INT NOT NULL REFERENCES districts(id) ON DELETE CASCADE,
+    issued_at TIMESTAMPTZ NOT NULL,
+    target_date DATE NOT NULL,
+    lead_days SMALLINT NOT NULL,
+    temp_2pm DOUBLE PRECISION,
+    pm25 DOUBLE PRECISION,
+    observed_temp_2pm DOUBLE PRECISION,
+    observed_pm25 DOUBLE PRECISION,
+    verified_at TIMESTAMPTZ,
+    PRIMARY KEY (district_id, issued_at, target_date)
+);
+
+CREATE INDEX IF NOT EXISTS forecast_snapshots_pending_idx
+    ON forecast_snapshots (target_date) WHERE verified_at IS NULL;
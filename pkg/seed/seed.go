@@ -0,0 +1,88 @@
+package seed
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"travel_advisor/domain"
+)
+
+// Districts is the bundled bd-districts.json dataset used when no source is given
+//
+//go:embed bd-districts.json
+var Districts []byte
+
+// ParseDistricts decodes a bd-districts.json payload and validates every row.
+// All row errors are collected so a broken dataset is reported in one pass.
+func ParseDistricts(bb []byte) ([]*domain.District, error) {
+	var payload domain.DistrictResponse
+	if err := json.Unmarshal(bb, &payload); err != nil {
+		return nil, fmt.Errorf("seed: invalid districts JSON: %v", err)
+	}
+	if len(payload.Districts) == 0 {
+		return nil, errors.New("seed: no districts found")
+	}
+
+	var (
+		districts = make([]*domain.District, 0, len(payload.Districts))
+		rowErrs   []error
+		seenIDs   = make(map[int64]bool)
+		seenNames = make(map[string]bool)
+	)
+	for i, d := range payload.Districts {
+		district, err := parseDistrict(d)
+		if err == nil && seenIDs[district.ID] {
+			err = fmt.Errorf("duplicate id %d", district.ID)
+		}
+		if err == nil && seenNames[strings.ToLower(district.Name)] {
+			err = fmt.Errorf("duplicate name %q", district.Name)
+		}
+		if err != nil {
+			rowErrs = append(rowErrs, fmt.Errorf("row %d (%s): %v", i+1, d.Name, err))
+			continue
+		}
+		seenIDs[district.ID] = true
+		seenNames[strings.ToLower(district.Name)] = true
+		districts = append(districts, district)
+	}
+
+	if len(rowErrs) > 0 {
+		return nil, errors.Join(rowErrs...)
+	}
+	return districts, nil
+}
+
+func parseDistrict(d domain.DistrictJSON) (*domain.District, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(d.Id), 10, 64)
+	if err != nil || id <= 0 {
+		return nil, fmt.Errorf("invalid id %q", d.Id)
+	}
+	divisionID, err := strconv.Atoi(strings.TrimSpace(d.DivisionID))
+	if err != nil || divisionID <= 0 {
+		return nil, fmt.Errorf("invalid division_id %q", d.DivisionID)
+	}
+	name := strings.TrimSpace(d.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(d.Lat), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("invalid lat %q", d.Lat)
+	}
+	long, err := strconv.ParseFloat(strings.TrimSpace(d.Long), 64)
+	if err != nil || long < -180 || long > 180 {
+		return nil, fmt.Errorf("invalid long %q", d.Long)
+	}
+
+	return &domain.District{
+		ID:         id,
+		DivisionID: divisionID,
+		Name:       name,
+		BnName:     strings.TrimSpace(d.BnName),
+		Lat:        lat,
+		Long:       long,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDistricts_Embedded(t *testing.T) {
+	districts, err := ParseDistricts(Districts)
+
+	assert.NoError(t, err)
+	assert.Len(t, districts, 64)
+}
+
+func TestParseDistricts_RowErrors(t *testing.T) {
+	payload := `{"districts":[
+		{"id":"1","division_id":"1","name":"Comilla","bn_name":"","lat":"23.46","long":"91.17"},
+		{"id":"x","division_id":"1","name":"Feni","bn_name":"","lat":"23.02","long":"91.38"},
+		{"id":"3","division_id":"1","name":"Comilla","bn_name":"","lat":"23.95","long":"91.11"},
+		{"id":"4","division_id":"1","name":"Rangamati","bn_name":"","lat":"abc","long":"92.17"}
+	]}`
+
+	districts, err := ParseDistricts([]byte(payload))
+
+	assert.Nil(t, districts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `row 2 (Feni): invalid id "x"`)
+	assert.Contains(t, err.Error(), `row 3 (Comilla): duplicate name "Comilla"`)
+	assert.Contains(t, err.Error(), `row 4 (Rangamati): invalid lat "abc"`)
+}
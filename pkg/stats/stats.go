@@ -0,0 +1,88 @@
+// Package stats reduces samples to summary statistics
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+type Statistic string
+
+const (
+	Mean   Statistic = "mean"
+	Median Statistic = "median"
+	P90    Statistic = "p90"
+	Max    Statistic = "max"
+)
+
+var ErrUnknownStatistic = errors.New("stats: unknown statistic")
+
+// ParseStatistic validates a configured statistic name, empty means Mean
+func ParseStatistic(s string) (Statistic, error) {
+	switch st := Statistic(s); st {
+	case "":
+		return Mean, nil
+	case Mean, Median, P90, Max:
+		return st, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownStatistic, s)
+	}
+}
+
+// Summary describes the distribution of a set of samples
+type Summary struct {
+	Count  int
+	Mean   float64
+	Median float64
+	Min    float64
+	Max    float64
+	P90    float64
+}
+
+// Summarize computes the summary of vals, false when there are none
+func Summarize(vals []float64) (Summary, bool) {
+	if len(vals) == 0 {
+		return Summary{}, false
+	}
+	sorted := append([]float64(nil), vals...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	return Summary{
+		Count:  len(sorted),
+		Mean:   sum / float64(len(sorted)),
+		Median: Percentile(sorted, 50),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		P90:    Percentile(sorted, 90),
+	}, true
+}
+
+// Value returns the statistic of the summary
+func (s Summary) Value(st Statistic) float64 {
+	switch st {
+	case Median:
+		return s.Median
+	case P90:
+		return s.P90
+	case Max:
+		return s.Max
+	default:
+		return s.Mean
+	}
+}
+
+// Percentile interpolates linearly between the closest ranks of sorted, p in 0-100
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lo, hi := int(math.Floor(rank)), int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
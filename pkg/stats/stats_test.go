@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize(t *testing.T) {
+	s, ok := Summarize([]float64{30, 26, 28, 35, 31})
+
+	assert.True(t, ok)
+	assert.Equal(t, 5, s.Count)
+	assert.InDelta(t, 30, s.Mean, 1e-9)
+	assert.Equal(t, 30.0, s.Median)
+	assert.Equal(t, 26.0, s.Min)
+	assert.Equal(t, 35.0, s.Max)
+	// rank 3.6 between 31 and 35
+	assert.InDelta(t, 33.4, s.P90, 1e-9)
+
+	_, ok = Summarize(nil)
+	assert.False(t, ok)
+}
+
+func TestSummary_Value(t *testing.T) {
+	s := Summary{Mean: 1, Median: 2, P90: 3, Max: 4}
+
+	tests := []struct {
+		stat     Statistic
+		expected float64
+	}{
+		{Mean, 1}, {Median, 2}, {P90, 3}, {Max, 4},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.stat), func(t *testing.T) {
+			assert.Equal(t, tt.expected, s.Value(tt.stat))
+		})
+	}
+}
+
+func TestParseStatistic(t *testing.T) {
+	st, err := ParseStatistic("")
+	assert.NoError(t, err)
+	assert.Equal(t, Mean, st)
+
+	st, err = ParseStatistic("p90")
+	assert.NoError(t, err)
+	assert.Equal(t, P90, st)
+
+	_, err = ParseStatistic("mode")
+	assert.ErrorIs(t, err, ErrUnknownStatistic)
+}
@@ -2,7 +2,10 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"travel_advisor/domain"
 	"travel_advisor/helpers"
 	"travel_advisor/travel/transformer"
@@ -11,27 +14,45 @@ import (
 )
 
 type TravelHandler struct {
-	TravelUsecase domain.TravelUsecase
+	TravelUsecase   domain.TravelUsecase
+	DistrictUsecase domain.DistrictUsecase
 }
 
-func NewTravelHandler(r *chi.Mux, t domain.TravelUsecase) {
+func NewTravelHandler(r *chi.Mux, t domain.TravelUsecase, d domain.DistrictUsecase) {
 	handler := &TravelHandler{
-		TravelUsecase: t,
+		TravelUsecase:   t,
+		DistrictUsecase: d,
 	}
 	r.Route("/v1/travel", func(r chi.Router) {
 		r.Use(helpers.JWTAuthMiddleware)
 		r.Get("/coolest/districts", handler.List)
 		r.Post("/recommend", handler.Recommend)
+		r.Post("/compare", handler.Compare)
+		r.Post("/best-date", handler.BestDate)
+		r.Get("/districts/{id}/hourly", handler.Hourly)
+		r.Post("/meetup", handler.Meetup)
+		r.Post("/itinerary", handler.Itinerary)
 	})
 }
 
 func (h *TravelHandler) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	districts, err := h.TravelUsecase.CoolestDistricts(ctx)
+	ctr, err := parseCoolestCriteria(r)
 	if err != nil {
 		resp := &helpers.Response{
-			Status:  http.StatusInternalServerError,
+			Status:  http.StatusBadRequest,
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	districts, err := h.TravelUsecase.CoolestDistricts(ctx, ctr)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  travelErrorStatus(err),
 			Message: "coolest districts fetch failed",
 			Error:   err.Error(),
 		}
@@ -39,6 +60,25 @@ func (h *TravelHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if helpers.AcceptsGeoJSON(r) {
+		all, err := h.DistrictUsecase.List(ctx)
+		if err != nil {
+			resp := &helpers.Response{
+				Status:  http.StatusInternalServerError,
+				Message: "district geometry fetch failed",
+				Error:   err.Error(),
+			}
+			resp.Render(w)
+			return
+		}
+		resp := &helpers.GeoJSONResponse{
+			Status: http.StatusOK,
+			Data:   transformer.TransformCoolestDistrictFeatureCollection(districts, all),
+		}
+		resp.Render(w)
+		return
+	}
+
 	resp := &helpers.Response{
 		Status: http.StatusOK,
 		Data:   transformer.TransformCoolestDistrictResponse(districts),
@@ -77,3 +117,207 @@ func (h *TravelHandler) Recommend(w http.ResponseWriter, r *http.Request) {
 	}
 	resp.Render(w)
 }
+
+func (h *TravelHandler) Compare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req domain.TravelCompareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	result, err := h.TravelUsecase.CompareDestinations(ctx, req)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  travelErrorStatus(err),
+			Message: "Travel comparison failed",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   result,
+	}
+	resp.Render(w)
+}
+
+func (h *TravelHandler) BestDate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req domain.BestDateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	result, err := h.TravelUsecase.BestTravelDate(ctx, req)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  travelErrorStatus(err),
+			Message: "Best travel date search failed",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   result,
+	}
+	resp.Render(w)
+}
+
+func (h *TravelHandler) Hourly(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid district id",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	result, err := h.TravelUsecase.HourlyForecast(ctx, id, r.URL.Query().Get("date"))
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  travelErrorStatus(err),
+			Message: "Hourly forecast fetch failed",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   result,
+	}
+	resp.Render(w)
+}
+
+func (h *TravelHandler) Meetup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req domain.MeetupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	result, err := h.TravelUsecase.Meetup(ctx, req)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  travelErrorStatus(err),
+			Message: "Meetup recommendation failed",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   result,
+	}
+	resp.Render(w)
+}
+
+func (h *TravelHandler) Itinerary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req domain.ItineraryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp := &helpers.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	result, err := h.TravelUsecase.Itinerary(ctx, req)
+	if err != nil {
+		resp := &helpers.Response{
+			Status:  travelErrorStatus(err),
+			Message: "Itinerary planning failed",
+			Error:   err.Error(),
+		}
+		resp.Render(w)
+		return
+	}
+
+	resp := &helpers.Response{
+		Status: http.StatusOK,
+		Data:   result,
+	}
+	resp.Render(w)
+}
+
+// parseCoolestCriteria reads the optional lat, long, radius_km, sort and
+// rain_tolerance query parameters
+func parseCoolestCriteria(r *http.Request) (*domain.CoolestCriteria, error) {
+	q := r.URL.Query()
+	ctr := &domain.CoolestCriteria{SortBy: q.Get("sort"), RainTolerance: q.Get("rain_tolerance")}
+
+	for key, dst := range map[string]**float64{"lat": &ctr.Lat, "long": &ctr.Long} {
+		if q.Get(key) == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(q.Get(key), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", key, err)
+		}
+		*dst = &v
+	}
+	if q.Get("radius_km") != "" {
+		v, err := strconv.ParseFloat(q.Get("radius_km"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid radius_km: %v", err)
+		}
+		ctr.RadiusKm = v
+	}
+	return ctr, nil
+}
+
+// travelErrorStatus maps request validation errors to 4xx, anything else is a server error
+func travelErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrNoDestinations), errors.Is(err, domain.ErrTooManyDestinations),
+		errors.Is(err, domain.ErrInvalidDateRange), errors.Is(err, domain.ErrInvalidTravelDate),
+		errors.Is(err, domain.ErrInvalidLocation), errors.Is(err, domain.ErrInvalidSort),
+		errors.Is(err, domain.ErrNoOrigins), errors.Is(err, domain.ErrTooManyOrigins),
+		errors.Is(err, domain.ErrInvalidObjective), errors.Is(err, domain.ErrNoStops),
+		errors.Is(err, domain.ErrTooManyStops), errors.Is(err, domain.ErrTooManyCandidates),
+		errors.Is(err, domain.ErrInvalidRainTolerance):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrDestinationNotFound), errors.Is(err, domain.ErrDistrictNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
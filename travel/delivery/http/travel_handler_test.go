@@ -20,8 +20,8 @@ type MockTravelUsecase struct {
 	mock.Mock
 }
 
-func (m *MockTravelUsecase) CoolestDistricts(ctx context.Context) ([]domain.DistrictCache, error) {
-	args := m.Called(ctx)
+func (m *MockTravelUsecase) CoolestDistricts(ctx context.Context, ctr *domain.CoolestCriteria) ([]domain.DistrictCache, error) {
+	args := m.Called(ctx, ctr)
 	return args.Get(0).([]domain.DistrictCache), args.Error(1)
 }
 
@@ -33,29 +33,143 @@ func (m *MockTravelUsecase) RecommendTravel(ctx context.Context, req domain.Trav
 	return args.Get(0).(*domain.TravelRecommendationResponse), args.Error(1)
 }
 
+func (m *MockTravelUsecase) CompareDestinations(ctx context.Context, req domain.TravelCompareRequest) (*domain.TravelCompareResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TravelCompareResponse), args.Error(1)
+}
+
+func (m *MockTravelUsecase) BestTravelDate(ctx context.Context, req domain.BestDateRequest) (*domain.BestDateResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BestDateResponse), args.Error(1)
+}
+
+func (m *MockTravelUsecase) HourlyForecast(ctx context.Context, districtID int64, date string) (*domain.HourlyForecastResponse, error) {
+	args := m.Called(ctx, districtID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.HourlyForecastResponse), args.Error(1)
+}
+
+func (m *MockTravelUsecase) Meetup(ctx context.Context, req domain.MeetupRequest) (*domain.MeetupResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MeetupResponse), args.Error(1)
+}
+
+func (m *MockTravelUsecase) Itinerary(ctx context.Context, req domain.ItineraryRequest) (*domain.ItineraryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ItineraryResponse), args.Error(1)
+}
+
+// Mock DistrictUsecase
+type MockDistrictUsecase struct {
+	mock.Mock
+}
+
+func (m *MockDistrictUsecase) List(ctx context.Context) ([]*domain.District, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*domain.District), args.Error(1)
+}
+
+func (m *MockDistrictUsecase) Get(ctx context.Context, id int64) (*domain.District, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.District), args.Error(1)
+}
+
+func (m *MockDistrictUsecase) Locate(ctx context.Context, lat, long float64) (*domain.District, error) {
+	args := m.Called(ctx, lat, long)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.District), args.Error(1)
+}
+
+func (m *MockDistrictUsecase) History(ctx context.Context, ctr *domain.ObservationCriteria) (*domain.DistrictHistory, error) {
+	args := m.Called(ctx, ctr)
+	return args.Get(0).(*domain.DistrictHistory), args.Error(1)
+}
+
+func (m *MockDistrictUsecase) Forecast(ctx context.Context, id int64) (*domain.DistrictForecast, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*domain.DistrictForecast), args.Error(1)
+}
+
 func TestTravelHandler_List(t *testing.T) {
 	tests := []struct {
 		name           string
-		setupMocks     func(*MockTravelUsecase)
+		query          string
+		accept         string
+		setupMocks     func(*MockTravelUsecase, *MockDistrictUsecase)
 		expectedStatus int
 		expectedBody   string
 	}{
 		{
 			name: "Success - Returns coolest districts",
-			setupMocks: func(mockUsecase *MockTravelUsecase) {
+			setupMocks: func(mockUsecase *MockTravelUsecase, mockDistrictUsecase *MockDistrictUsecase) {
 				districts := []domain.DistrictCache{
-					{Name: "Sylhet", AvgTemp2PM: 26.8, AvgPM25: 25.5},
-					{Name: "Chittagong", AvgTemp2PM: 28.3, AvgPM25: 35.1},
+					{Name: "Sylhet", Temp: 26.8, PM25: 25.5},
+					{Name: "Chittagong", Temp: 28.3, PM25: 35.1},
 				}
-				mockUsecase.On("CoolestDistricts", mock.Anything).Return(districts, nil)
+				mockUsecase.On("CoolestDistricts", mock.Anything, &domain.CoolestCriteria{}).Return(districts, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody:   `"data":`,
 		},
+		{
+			name:   "Success - Returns GeoJSON feature collection",
+			accept: "application/geo+json",
+			setupMocks: func(mockUsecase *MockTravelUsecase, mockDistrictUsecase *MockDistrictUsecase) {
+				districts := []domain.DistrictCache{
+					{Name: "Sylhet", Temp: 26.8, PM25: 25.5},
+				}
+				mockUsecase.On("CoolestDistricts", mock.Anything, &domain.CoolestCriteria{}).Return(districts, nil)
+				mockDistrictUsecase.On("List", mock.Anything).Return([]*domain.District{
+					{ID: 36, Name: "Sylhet", Lat: 24.8949, Long: 91.8687},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"type":"FeatureCollection"`,
+		},
+		{
+			name:  "Success - Returns coolest districts near a location",
+			query: "?lat=23.7&long=90.4&radius_km=80&sort=distance",
+			setupMocks: func(mockUsecase *MockTravelUsecase, mockDistrictUsecase *MockDistrictUsecase) {
+				lat, long, dist := 23.7, 90.4, 42.5
+				ctr := &domain.CoolestCriteria{Lat: &lat, Long: &long, RadiusKm: 80, SortBy: domain.SortByDistance}
+				districts := []domain.DistrictCache{
+					{Name: "Manikganj", Temp: 27.1, PM25: 50.2, DistanceKm: &dist},
+				}
+				mockUsecase.On("CoolestDistricts", mock.Anything, ctr).Return(districts, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"distance_km":42.5`,
+		},
+		{
+			name:           "Error - Invalid lat",
+			query:          "?lat=north&long=90.4&radius_km=80",
+			setupMocks:     func(mockUsecase *MockTravelUsecase, mockDistrictUsecase *MockDistrictUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"message":"Invalid query parameters"`,
+		},
 		{
 			name: "Error - Usecase returns error",
-			setupMocks: func(mockUsecase *MockTravelUsecase) {
-				mockUsecase.On("CoolestDistricts", mock.Anything).Return([]domain.DistrictCache{}, errors.New("database error"))
+			setupMocks: func(mockUsecase *MockTravelUsecase, mockDistrictUsecase *MockDistrictUsecase) {
+				mockUsecase.On("CoolestDistricts", mock.Anything, &domain.CoolestCriteria{}).Return([]domain.DistrictCache{}, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   `"message":"coolest districts fetch failed"`,
@@ -65,13 +179,18 @@ func TestTravelHandler_List(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUsecase := new(MockTravelUsecase)
-			tt.setupMocks(mockUsecase)
+			mockDistrictUsecase := new(MockDistrictUsecase)
+			tt.setupMocks(mockUsecase, mockDistrictUsecase)
 
 			handler := &TravelHandler{
-				TravelUsecase: mockUsecase,
+				TravelUsecase:   mockUsecase,
+				DistrictUsecase: mockDistrictUsecase,
 			}
 
-			req := httptest.NewRequest("GET", "/v1/travel/coolest/districts", nil)
+			req := httptest.NewRequest("GET", "/v1/travel/coolest/districts"+tt.query, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
 			rr := httptest.NewRecorder()
 
 			handler.List(rr, req)
@@ -80,6 +199,7 @@ func TestTravelHandler_List(t *testing.T) {
 			assert.Contains(t, rr.Body.String(), tt.expectedBody)
 
 			mockUsecase.AssertExpectations(t)
+			mockDistrictUsecase.AssertExpectations(t)
 		})
 	}
 }
@@ -183,11 +303,335 @@ func TestTravelHandler_Recommend(t *testing.T) {
 	}
 }
 
+func TestTravelHandler_Compare(t *testing.T) {
+	req := domain.TravelCompareRequest{
+		CurrentLat:           23.7104,
+		CurrentLong:          90.3944,
+		DestinationDistricts: []string{"Sylhet", "Bandarban"},
+		TravelDate:           "2024-01-15",
+	}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*MockTravelUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success - Returns ranked destinations",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				resp := &domain.TravelCompareResponse{
+					TravelDate: "2024-01-15",
+					Destinations: []domain.ComparedDestination{
+						{Rank: 1, TravelRecommendationResponse: &domain.TravelRecommendationResponse{Destination: "Bandarban", Score: 80}},
+						{Rank: 2, TravelRecommendationResponse: &domain.TravelRecommendationResponse{Destination: "Sylhet", Score: 70}},
+					},
+				}
+				mockUsecase.On("CompareDestinations", mock.Anything, req).Return(resp, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"rank":1,"destination":"Bandarban"`,
+		},
+		{
+			name: "Error - Too many destinations",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				mockUsecase.On("CompareDestinations", mock.Anything, req).Return(nil, domain.ErrTooManyDestinations)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"message":"Travel comparison failed"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockTravelUsecase)
+			tt.setupMocks(mockUsecase)
+
+			handler := &TravelHandler{
+				TravelUsecase: mockUsecase,
+			}
+
+			var body bytes.Buffer
+			json.NewEncoder(&body).Encode(req)
+
+			r := httptest.NewRequest("POST", "/v1/travel/compare", &body)
+			rr := httptest.NewRecorder()
+
+			handler.Compare(rr, r)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTravelHandler_BestDate(t *testing.T) {
+	req := domain.BestDateRequest{
+		CurrentLat:          23.7104,
+		CurrentLong:         90.3944,
+		DestinationDistrict: "Sylhet",
+		DateFrom:            "2024-01-15",
+		DateTo:              "2024-01-18",
+	}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*MockTravelUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success - Returns ranked dates",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				resp := &domain.BestDateResponse{
+					Destination: "Sylhet",
+					Dates: []domain.RankedTravelDate{
+						{Rank: 1, Date: "2024-01-17", TravelRecommendationResponse: &domain.TravelRecommendationResponse{Destination: "Sylhet", Score: 80}},
+					},
+				}
+				mockUsecase.On("BestTravelDate", mock.Anything, req).Return(resp, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"rank":1,"date":"2024-01-17"`,
+		},
+		{
+			name: "Error - Outside the forecast horizon",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				mockUsecase.On("BestTravelDate", mock.Anything, req).Return(nil, domain.ErrInvalidDateRange)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"message":"Best travel date search failed"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockTravelUsecase)
+			tt.setupMocks(mockUsecase)
+
+			handler := &TravelHandler{
+				TravelUsecase: mockUsecase,
+			}
+
+			var body bytes.Buffer
+			json.NewEncoder(&body).Encode(req)
+
+			r := httptest.NewRequest("POST", "/v1/travel/best-date", &body)
+			rr := httptest.NewRecorder()
+
+			handler.BestDate(rr, r)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTravelHandler_Hourly(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMocks     func(*MockTravelUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success - Returns hourly series",
+			path: "/v1/travel/districts/47/hourly?date=2024-01-15",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				resp := &domain.HourlyForecastResponse{DistrictID: 47, District: "Sylhet", Date: "2024-01-15"}
+				mockUsecase.On("HourlyForecast", mock.Anything, int64(47), "2024-01-15").Return(resp, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"district":"Sylhet"`,
+		},
+		{
+			name:           "Error - Invalid district id",
+			path:           "/v1/travel/districts/abc/hourly",
+			setupMocks:     func(mockUsecase *MockTravelUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"message":"Invalid district id"`,
+		},
+		{
+			name: "Error - District not found",
+			path: "/v1/travel/districts/99/hourly",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				mockUsecase.On("HourlyForecast", mock.Anything, int64(99), "").Return(nil, domain.ErrDistrictNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `"message":"Hourly forecast fetch failed"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockTravelUsecase)
+			tt.setupMocks(mockUsecase)
+
+			handler := &TravelHandler{
+				TravelUsecase: mockUsecase,
+			}
+
+			router := chi.NewRouter()
+			router.Get("/v1/travel/districts/{id}/hourly", handler.Hourly)
+
+			r := httptest.NewRequest("GET", tt.path, nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, r)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTravelHandler_Meetup(t *testing.T) {
+	req := domain.MeetupRequest{
+		Origins:    []domain.Coordinate{{Lat: 23.7104, Long: 90.3944}, {Lat: 22.3569, Long: 91.7832}},
+		TravelDate: "2024-01-15",
+	}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*MockTravelUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success - Returns meeting points",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				resp := &domain.MeetupResponse{
+					TravelDate: "2024-01-15",
+					Objective:  domain.MeetupObjectiveTotal,
+					Suggestions: []domain.MeetupSuggestion{
+						{Rank: 1, District: "Cumilla", Score: 72.4},
+					},
+				}
+				mockUsecase.On("Meetup", mock.Anything, req).Return(resp, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"rank":1,"district":"Cumilla"`,
+		},
+		{
+			name: "Error - Too many origins",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				mockUsecase.On("Meetup", mock.Anything, req).Return(nil, domain.ErrTooManyOrigins)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"message":"Meetup recommendation failed"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockTravelUsecase)
+			tt.setupMocks(mockUsecase)
+
+			handler := &TravelHandler{
+				TravelUsecase: mockUsecase,
+			}
+
+			var body bytes.Buffer
+			json.NewEncoder(&body).Encode(req)
+
+			r := httptest.NewRequest("POST", "/v1/travel/meetup", &body)
+			rr := httptest.NewRecorder()
+
+			handler.Meetup(rr, r)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTravelHandler_Itinerary(t *testing.T) {
+	req := domain.ItineraryRequest{
+		Start:     domain.Coordinate{Lat: 23.7104, Long: 90.3944},
+		DateFrom:  "2024-01-15",
+		DateTo:    "2024-01-17",
+		MustVisit: []string{"Sylhet", "Moulvibazar"},
+		Optional:  []string{"Habiganj"},
+	}
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*MockTravelUsecase)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success - Returns the plan",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				resp := &domain.ItineraryResponse{
+					Start: req.Start,
+					Stops: []domain.ItineraryStop{
+						{Order: 1, Date: "2024-01-15", District: "Habiganj"},
+					},
+				}
+				mockUsecase.On("Itinerary", mock.Anything, req).Return(resp, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"order":1,"date":"2024-01-15","district":"Habiganj"`,
+		},
+		{
+			name: "Error - Too many stops",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				mockUsecase.On("Itinerary", mock.Anything, req).Return(nil, domain.ErrTooManyStops)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"message":"Itinerary planning failed"`,
+		},
+		{
+			name: "Error - Too many districts to visit",
+			setupMocks: func(mockUsecase *MockTravelUsecase) {
+				mockUsecase.On("Itinerary", mock.Anything, req).Return(nil, domain.ErrTooManyCandidates)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `"message":"Itinerary planning failed"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockTravelUsecase)
+			tt.setupMocks(mockUsecase)
+
+			handler := &TravelHandler{
+				TravelUsecase: mockUsecase,
+			}
+
+			var body bytes.Buffer
+			json.NewEncoder(&body).Encode(req)
+
+			r := httptest.NewRequest("POST", "/v1/travel/itinerary", &body)
+			rr := httptest.NewRecorder()
+
+			handler.Itinerary(rr, r)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
 func TestNewTravelHandler(t *testing.T) {
 	r := chi.NewRouter()
 	mockUsecase := new(MockTravelUsecase)
+	mockDistrictUsecase := new(MockDistrictUsecase)
 
 	assert.NotPanics(t, func() {
-		NewTravelHandler(r, mockUsecase)
+		NewTravelHandler(r, mockUsecase, mockDistrictUsecase)
 	})
 }
@@ -1,21 +1,125 @@
 package transformer
 
-import "travel_advisor/domain"
+import (
+	districtTransformer "travel_advisor/districts/transformer"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/geo"
+)
 
 type DistrictResponse struct {
-	Name       string  `json:"district_name"`
-	AvgTemp2PM float64 `json:"avg_temp_2_pm"`
-	AvgPM25    float64 `json:"avg_pm_25"`
+	Name       string   `json:"district_name"`
+	AvgTemp2PM float64  `json:"avg_temp_2_pm"`
+	AvgPM25    float64  `json:"avg_pm_25"`
+	FeelsLike  float64  `json:"feels_like"`
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+
+	// Temp2PM and PM25 are reduced with Statistic and rank the districts,
+	// the stats describe the samples behind them
+	Temp2PM   float64             `json:"temp_2_pm"`
+	PM25      float64             `json:"pm_25"`
+	Statistic string              `json:"statistic,omitempty"`
+	TempStats *domain.MetricStats `json:"temp_stats,omitempty"`
+	PM25Stats *domain.MetricStats `json:"pm25_stats,omitempty"`
+
+	Humidity2PM     *float64 `json:"humidity_2_pm,omitempty"`
+	ApparentTemp2PM *float64 `json:"apparent_temp_2_pm,omitempty"`
+	WindSpeed2PM    *float64 `json:"wind_speed_2_pm,omitempty"`
+	HeatIndex2PM    *float64 `json:"heat_index_2_pm,omitempty"`
+	WBGT2PM         *float64 `json:"wbgt_2_pm,omitempty"`
+
+	PrecipitationSum         *float64 `json:"precipitation_sum,omitempty"`
+	PrecipitationProbability *float64 `json:"precipitation_probability,omitempty"`
+	WeatherCode              *int     `json:"weather_code,omitempty"`
+	Rainy                    bool     `json:"rainy"`
+
+	AQI         *int   `json:"aqi,omitempty"`
+	AQICategory string `json:"aqi_category,omitempty"`
+
+	TempAnomaly *float64 `json:"temp_anomaly,omitempty"`
+	TempZScore  *float64 `json:"temp_z_score,omitempty"`
+	PM25Anomaly *float64 `json:"pm25_anomaly,omitempty"`
+	PM25ZScore  *float64 `json:"pm25_z_score,omitempty"`
 }
 
 func TransformCoolestDistrictResponse(gt []domain.DistrictCache) []DistrictResponse {
 	resp := make([]DistrictResponse, 0)
 	for _, g := range gt {
-		resp = append(resp, DistrictResponse{
+		r := DistrictResponse{
 			Name:       g.Name,
-			AvgTemp2PM: g.AvgTemp2PM,
-			AvgPM25:    g.AvgPM25,
-		})
+			AvgTemp2PM: g.MeanTemp(),
+			AvgPM25:    g.MeanPM25(),
+			DistanceKm: g.DistanceKm,
+			FeelsLike:  g.FeelsLike(),
+
+			Temp2PM:   g.Temp,
+			PM25:      g.PM25,
+			Statistic: g.Statistic,
+			TempStats: g.TempStats,
+			PM25Stats: g.PM25Stats,
+
+			Humidity2PM:     g.Humidity,
+			ApparentTemp2PM: g.ApparentTemp,
+			WindSpeed2PM:    g.WindSpeed,
+			HeatIndex2PM:    g.HeatIndex,
+			WBGT2PM:         g.WBGT,
+
+			PrecipitationSum:         g.AvgPrecipitationSum,
+			PrecipitationProbability: g.AvgPrecipitationProbability,
+			WeatherCode:              g.WeatherCode,
+			Rainy:                    g.Rainy(),
+		}
+		if g.AQI != nil {
+			r.AQI = &g.AQI.AQI
+			r.AQICategory = g.AQI.Category
+		}
+		if g.Anomaly != nil {
+			r.TempAnomaly = g.Anomaly.TempAnomaly
+			r.TempZScore = g.Anomaly.TempZScore
+			r.PM25Anomaly = g.Anomaly.PM25Anomaly
+			r.PM25ZScore = g.Anomaly.PM25ZScore
+		}
+		resp = append(resp, r)
 	}
 	return resp
 }
+
+// TransformCoolestDistrictFeatureCollection joins the ranking with the district
+// geometries so a map can color each district by its rank
+func TransformCoolestDistrictFeatureCollection(gt []domain.DistrictCache, districts []*domain.District) *geo.FeatureCollection {
+	byName := make(map[string]*domain.District, len(districts))
+	for _, d := range districts {
+		byName[d.Name] = d
+	}
+
+	fc := geo.NewFeatureCollection()
+	for i, g := range gt {
+		d, ok := byName[g.Name]
+		if !ok {
+			continue
+		}
+		props := map[string]interface{}{
+			"rank":          i + 1,
+			"avg_temp_2_pm": g.MeanTemp(),
+			"avg_pm_25":     g.MeanPM25(),
+			"temp_2_pm":     g.Temp,
+			"pm_25":         g.PM25,
+			"feels_like":    g.FeelsLike(),
+			"rainy":         g.Rainy(),
+		}
+		if g.DistanceKm != nil {
+			props["distance_km"] = *g.DistanceKm
+		}
+		if g.AQI != nil {
+			props["aqi"] = g.AQI.AQI
+			props["aqi_category"] = g.AQI.Category
+		}
+		if g.Anomaly != nil {
+			props["temp_anomaly"] = g.Anomaly.TempAnomaly
+			props["temp_z_score"] = g.Anomaly.TempZScore
+			props["pm25_anomaly"] = g.Anomaly.PM25Anomaly
+			props["pm25_z_score"] = g.Anomaly.PM25ZScore
+		}
+		fc.Features = append(fc.Features, districtTransformer.TransformDistrictFeature(d, props))
+	}
+	return fc
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/geo"
+	"travel_advisor/pkg/log"
+)
+
+const (
+	defaultAlternativesRadiusKm = 100
+	defaultMaxAlternatives      = 3
+)
+
+// alternatives suggests cached districts near the destination or the origin.
+// They are a hint on top of the recommendation, so failures only skip them.
+func (t *TravelUsecase) alternatives(
+	ctx context.Context,
+	dest *domain.District,
+	originLat, originLong float64,
+	origin *domain.TravelConditions,
+) []domain.Alternative {
+
+	cached, err := t.cachedDistricts(ctx)
+	if err != nil {
+		log.Warn("cached districts fetch failed ", err)
+		return nil
+	}
+	all, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{})
+	if err != nil {
+		log.Warn("districts fetch failed ", err)
+		return nil
+	}
+
+	cfg := config.Recommendation()
+	radius, limit := cfg.AlternativesRadiusKm, cfg.MaxAlternatives
+	if radius <= 0 {
+		radius = defaultAlternativesRadiusKm
+	}
+	if limit <= 0 {
+		limit = defaultMaxAlternatives
+	}
+
+	return SuggestAlternatives(cached, all, dest, originLat, originLong, origin, radius, limit)
+}
+
+// SuggestAlternatives picks up to limit cached districts, other than the
+// destination, that are cooler and cleaner than the origin and lie within
+// radiusKm of the destination or the origin. The coolest come first.
+func SuggestAlternatives(
+	cached []domain.DistrictCache,
+	districts []*domain.District,
+	dest *domain.District,
+	originLat, originLong float64,
+	origin *domain.TravelConditions,
+	radiusKm float64,
+	limit int,
+) []domain.Alternative {
+
+	byName := make(map[string]*domain.District, len(districts))
+	for _, d := range districts {
+		byName[d.Name] = d
+	}
+
+	var suggested []domain.Alternative
+	for _, c := range cached {
+		d, ok := byName[c.Name]
+		if !ok || d.Name == dest.Name {
+			continue
+		}
+		if c.Temp >= origin.Temp2PM || c.PM25 >= origin.PM25 {
+			continue
+		}
+
+		fromDest := geo.Distance(dest.Lat, dest.Long, d.Lat, d.Long)
+		fromOrigin := geo.Distance(originLat, originLong, d.Lat, d.Long)
+		if fromDest > radiusKm && fromOrigin > radiusKm {
+			continue
+		}
+
+		suggested = append(suggested, domain.Alternative{
+			District:                  d.Name,
+			DistanceFromDestinationKm: round1(fromDest),
+			DistanceFromOriginKm:      round1(fromOrigin),
+			AvgTemp2PM:                c.MeanTemp(),
+			AvgPM25:                   c.MeanPM25(),
+		})
+	}
+
+	sort.SliceStable(suggested, func(i, j int) bool {
+		if suggested[i].AvgTemp2PM != suggested[j].AvgTemp2PM {
+			return suggested[i].AvgTemp2PM < suggested[j].AvgTemp2PM
+		}
+		if suggested[i].AvgPM25 != suggested[j].AvgPM25 {
+			return suggested[i].AvgPM25 < suggested[j].AvgPM25
+		}
+		return suggested[i].DistanceFromDestinationKm < suggested[j].DistanceFromDestinationKm
+	})
+
+	if len(suggested) > limit {
+		suggested = suggested[:limit]
+	}
+	return suggested
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/conn"
+)
+
+// BestTravelDate scores the destination against the origin for every day of
+// the window and ranks the days, best first
+func (t *TravelUsecase) BestTravelDate(
+	ctx context.Context,
+	req domain.BestDateRequest,
+) (*domain.BestDateResponse, error) {
+
+	if err := ValidateDateWindow(req.DateFrom, req.DateTo, today()); err != nil {
+		return nil, err
+	}
+	cfg, err := ApplyRainTolerance(config.Recommendation(), req.RainTolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	districts, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{
+		DistrictName: &req.DestinationDistrict,
+	})
+	if err != nil || len(districts) == 0 {
+		return nil, domain.ErrDestinationNotFound
+	}
+	destDistrict := districts[0]
+
+	client := conn.GetHTTClient()
+
+	var (
+		dest, current       map[string]*domain.TravelConditions
+		errDest, errCurrent error
+		wg                  sync.WaitGroup
+	)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		dest, errDest = helpers.FetchDailyTravelConditions(ctx, client, destDistrict.Lat, destDistrict.Long, req.DateFrom, req.DateTo, scoresUV(cfg))
+	}()
+	go func() {
+		defer wg.Done()
+		current, errCurrent = helpers.FetchDailyTravelConditions(ctx, client, req.CurrentLat, req.CurrentLong, req.DateFrom, req.DateTo, false)
+	}()
+	wg.Wait()
+
+	if errDest != nil {
+		return nil, errDest
+	}
+	if errCurrent != nil {
+		return nil, errCurrent
+	}
+
+	days := make([]string, 0, len(dest))
+	for date := range dest {
+		days = append(days, date)
+	}
+	normals := t.climateNormals(ctx, days...)
+
+	dates := make([]domain.RankedTravelDate, 0, len(dest))
+	for date, d := range dest {
+		c, ok := current[date]
+		if !ok {
+			continue
+		}
+		dates = append(dates, domain.RankedTravelDate{
+			Date:                         date,
+			OriginMetrics:                c,
+			DestinationMetrics:           d,
+			TravelRecommendationResponse: t.buildRecommendation(destDistrict, c, d, normals[date][destDistrict.ID], cfg),
+		})
+	}
+	if len(dates) == 0 {
+		return nil, fmt.Errorf("no forecast data between %s and %s", req.DateFrom, req.DateTo)
+	}
+	RankTravelDates(dates)
+
+	return &domain.BestDateResponse{
+		Destination: destDistrict.Name,
+		Dates:       dates,
+	}, nil
+}
+
+// RankTravelDates orders by score, breaking ties on the cooler and then the earlier day
+func RankTravelDates(dates []domain.RankedTravelDate) {
+	sort.SliceStable(dates, func(i, j int) bool {
+		if dates[i].Score != dates[j].Score {
+			return dates[i].Score > dates[j].Score
+		}
+		if dates[i].TempDiff != dates[j].TempDiff {
+			return dates[i].TempDiff < dates[j].TempDiff
+		}
+		return dates[i].Date < dates[j].Date
+	})
+	for i := range dates {
+		dates[i].Rank = i + 1
+	}
+}
+
+// ValidateDateWindow checks that from..to is an ordered range of YYYY-MM-DD
+// dates inside the forecast horizon starting at today
+func ValidateDateWindow(from, to string, today time.Time) error {
+	f, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return fmt.Errorf("%w: date_from must be YYYY-MM-DD", domain.ErrInvalidDateRange)
+	}
+	tt, err := time.Parse(time.DateOnly, to)
+	if err != nil {
+		return fmt.Errorf("%w: date_to must be YYYY-MM-DD", domain.ErrInvalidDateRange)
+	}
+
+	first := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 0, helpers.ForecastHorizonDays-1)
+	switch {
+	case tt.Before(f):
+		return fmt.Errorf("%w: date_to is before date_from", domain.ErrInvalidDateRange)
+	case f.Before(first), tt.After(last):
+		return fmt.Errorf("%w: dates must be between %s and %s",
+			domain.ErrInvalidDateRange, first.Format(time.DateOnly), last.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// today is the current date in the application timezone
+func today() time.Time {
+	loc, err := time.LoadLocation(config.App().Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return time.Now().In(loc)
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/conn"
+)
+
+const defaultMaxCompareDestinations = 5
+
+// CompareDestinations scores every candidate against the same origin. The
+// origin and each distinct destination are fetched once, concurrently.
+func (t *TravelUsecase) CompareDestinations(
+	ctx context.Context,
+	req domain.TravelCompareRequest,
+) (*domain.TravelCompareResponse, error) {
+
+	cfg, err := ApplyRainTolerance(config.Recommendation(), req.RainTolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	names := uniqueNames(req.DestinationDistricts)
+	maxDestinations := cfg.MaxCompareDestinations
+	if maxDestinations <= 0 {
+		maxDestinations = defaultMaxCompareDestinations
+	}
+	switch {
+	case len(names) == 0:
+		return nil, domain.ErrNoDestinations
+	case len(names) > maxDestinations:
+		return nil, fmt.Errorf("%w: at most %d allowed", domain.ErrTooManyDestinations, maxDestinations)
+	}
+
+	all, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{})
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.District, len(all))
+	for _, d := range all {
+		byName[d.Name] = d
+	}
+	destinations := make([]*domain.District, 0, len(names))
+	for _, name := range names {
+		d, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrDestinationNotFound, name)
+		}
+		destinations = append(destinations, d)
+	}
+
+	client := conn.GetHTTClient()
+
+	var (
+		current    *domain.TravelConditions
+		errCurrent error
+		conds      = make([]*domain.TravelConditions, len(destinations))
+		errs       = make([]error, len(destinations))
+		wg         sync.WaitGroup
+	)
+
+	wg.Add(1 + len(destinations))
+	go func() {
+		defer wg.Done()
+		current, errCurrent = helpers.FetchTravelConditions(ctx, client, req.CurrentLat, req.CurrentLong, req.TravelDate, false)
+	}()
+	for i, d := range destinations {
+		i, d := i, d
+		go func() {
+			defer wg.Done()
+			conds[i], errs[i] = helpers.FetchTravelConditions(ctx, client, d.Lat, d.Long, req.TravelDate, scoresUV(cfg))
+		}()
+	}
+	wg.Wait()
+
+	if errCurrent != nil {
+		return nil, errCurrent
+	}
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", destinations[i].Name, err)
+		}
+	}
+
+	normals := t.climateNormals(ctx, req.TravelDate)
+	compared := make([]domain.ComparedDestination, 0, len(destinations))
+	for i, d := range destinations {
+		compared = append(compared, domain.ComparedDestination{
+			TravelRecommendationResponse: t.buildRecommendation(d, current, conds[i], normals[req.TravelDate][d.ID], cfg),
+		})
+	}
+	RankComparedDestinations(compared)
+
+	return &domain.TravelCompareResponse{
+		TravelDate:   req.TravelDate,
+		Destinations: compared,
+	}, nil
+}
+
+// RankComparedDestinations orders by score, breaking ties on the cooler destination
+func RankComparedDestinations(compared []domain.ComparedDestination) {
+	sort.SliceStable(compared, func(i, j int) bool {
+		if compared[i].Score == compared[j].Score {
+			return compared[i].TempDiff < compared[j].TempDiff
+		}
+		return compared[i].Score > compared[j].Score
+	})
+	for i := range compared {
+		compared[i].Rank = i + 1
+	}
+}
+
+func uniqueNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	unique := make([]string, 0, len(names))
+	for _, n := range names {
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		unique = append(unique, n)
+	}
+	return unique
+}
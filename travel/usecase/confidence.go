@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/log"
+)
+
+// defaultEnsemble is used for anything config.yml leaves unset
+var defaultEnsemble = config.EnsembleCfg{
+	Models:                 []string{"ecmwf_ifs025", "gfs_seamless", "icon_seamless"},
+	MaxTempSpread:          3,
+	MaxPrecipitationSpread: 10,
+	LeadDecayPerDay:        0.08,
+}
+
+const (
+	// minLeadFactor keeps distant forecasts from being rated worthless on lead time alone
+	minLeadFactor = 0.2
+	// disagreementWeight is the share of the confidence full model disagreement removes
+	disagreementWeight = 0.6
+)
+
+func ensembleConfig() config.EnsembleCfg {
+	cfg := config.Ensemble()
+	if len(cfg.Models) < 2 {
+		cfg.Models = defaultEnsemble.Models
+	}
+	if cfg.MaxTempSpread <= 0 {
+		cfg.MaxTempSpread = defaultEnsemble.MaxTempSpread
+	}
+	if cfg.MaxPrecipitationSpread <= 0 {
+		cfg.MaxPrecipitationSpread = defaultEnsemble.MaxPrecipitationSpread
+	}
+	if cfg.LeadDecayPerDay <= 0 {
+		cfg.LeadDecayPerDay = defaultEnsemble.LeadDecayPerDay
+	}
+	return cfg
+}
+
+// forecastConfidence fetches the origin and destination ensembles of a
+// forecast date. Ensembles only refine the confidence, so failures leave it
+// to the lead time.
+func (t *TravelUsecase) forecastConfidence(
+	ctx context.Context,
+	client *http.Client,
+	originLat, originLong float64,
+	dest *domain.District,
+	date string,
+) *domain.ForecastConfidence {
+
+	leadDays, err := leadDaysUntil(date, today())
+	if err != nil {
+		return nil
+	}
+	cfg := ensembleConfig()
+	if leadDays < 0 || helpers.WeatherEndpointFor(date) != helpers.WeatherForecastURL {
+		return ForecastConfidence(leadDays, nil, nil, cfg)
+	}
+
+	var (
+		origin, destination *domain.EnsembleForecast
+		wg                  sync.WaitGroup
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		e, err := helpers.FetchEnsemble(ctx, client, originLat, originLong, date, cfg.Models)
+		if err != nil {
+			log.Warn("origin ensemble fetch failed ", err)
+			return
+		}
+		origin = e
+	}()
+	go func() {
+		defer wg.Done()
+		e, err := helpers.FetchEnsemble(ctx, client, dest.Lat, dest.Long, date, cfg.Models)
+		if err != nil {
+			log.Warn("destination ensemble fetch failed ", dest.Name, err)
+			return
+		}
+		destination = e
+	}()
+	wg.Wait()
+
+	return ForecastConfidence(leadDays, origin, destination, cfg)
+}
+
+// ForecastConfidence rates a forecast leadDays ahead. Forecasts for today and
+// tomorrow start fully trusted and lose LeadDecayPerDay for every further
+// day; the largest model spread, relative to its limit, then removes up to
+// disagreementWeight of what is left.
+func ForecastConfidence(leadDays int, origin, dest *domain.EnsembleForecast, cfg config.EnsembleCfg) *domain.ForecastConfidence {
+	score := math.Max(minLeadFactor, 1-float64(max(0, leadDays-1))*cfg.LeadDecayPerDay)
+
+	conf := &domain.ForecastConfidence{
+		LeadDays:    leadDays,
+		ScoredModel: helpers.DefaultWeatherModel,
+		Origin:      origin,
+		Destination: dest,
+	}
+
+	var (
+		disagreement float64
+		found        bool
+	)
+	for _, e := range []*domain.EnsembleForecast{origin, dest} {
+		if e == nil {
+			continue
+		}
+		if e.Temp2PM != nil {
+			disagreement, found = math.Max(disagreement, e.Temp2PM.Spread/cfg.MaxTempSpread), true
+		}
+		if e.PrecipitationSum != nil {
+			disagreement, found = math.Max(disagreement, e.PrecipitationSum.Spread/cfg.MaxPrecipitationSpread), true
+		}
+	}
+	if found {
+		disagreement = math.Round(math.Min(1, disagreement)*100) / 100
+		conf.Disagreement = &disagreement
+		score *= 1 - disagreementWeight*disagreement
+	}
+
+	conf.Score = math.Round(score*100) / 100
+	switch {
+	case conf.Score >= 0.75:
+		conf.Level = domain.ConfidenceHigh
+	case conf.Score >= 0.5:
+		conf.Level = domain.ConfidenceMedium
+	default:
+		conf.Level = domain.ConfidenceLow
+	}
+	return conf
+}
+
+// leadDaysUntil is how many days after today date (YYYY-MM-DD) is
+func leadDaysUntil(date string, today time.Time) (int, error) {
+	d, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return 0, err
+	}
+	first := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	return int(d.Sub(first).Hours() / 24), nil
+}
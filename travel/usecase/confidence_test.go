@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastConfidence(t *testing.T) {
+	tests := []struct {
+		name                 string
+		leadDays             int
+		origin, dest         *domain.EnsembleForecast
+		expectedScore        float64
+		expectedLevel        string
+		expectedDisagreement *float64
+	}{
+		{name: "Tomorrow without an ensemble", leadDays: 1, expectedScore: 1, expectedLevel: domain.ConfidenceHigh},
+		{name: "A week out", leadDays: 7, expectedScore: 0.52, expectedLevel: domain.ConfidenceMedium},
+		{name: "Beyond the decay floor", leadDays: 15, expectedScore: 0.2, expectedLevel: domain.ConfidenceLow},
+		{
+			name:     "Models disagree on temperature",
+			leadDays: 2,
+			dest:     &domain.EnsembleForecast{Temp2PM: &domain.EnsembleStat{Mean: 33, Spread: 1.5, Members: 3}},
+			// 0.92 * (1 - 0.6*0.5)
+			expectedScore:        0.64,
+			expectedLevel:        domain.ConfidenceMedium,
+			expectedDisagreement: floatPtr(0.5),
+		},
+		{
+			name:                 "Disagreement is capped",
+			leadDays:             0,
+			origin:               &domain.EnsembleForecast{PrecipitationSum: &domain.EnsembleStat{Mean: 15, Spread: 20, Members: 3}},
+			dest:                 &domain.EnsembleForecast{Temp2PM: &domain.EnsembleStat{Mean: 30, Spread: 0.3, Members: 3}},
+			expectedScore:        0.4,
+			expectedLevel:        domain.ConfidenceLow,
+			expectedDisagreement: floatPtr(1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := ForecastConfidence(tt.leadDays, tt.origin, tt.dest, defaultEnsemble)
+
+			assert.Equal(t, tt.leadDays, conf.LeadDays)
+			assert.InDelta(t, tt.expectedScore, conf.Score, 1e-9)
+			assert.Equal(t, tt.expectedLevel, conf.Level)
+			assert.Equal(t, tt.expectedDisagreement, conf.Disagreement)
+			assert.Equal(t, helpers.DefaultWeatherModel, conf.ScoredModel)
+		})
+	}
+}
+
+func TestLeadDaysUntil(t *testing.T) {
+	today := time.Date(2024, 5, 1, 23, 30, 0, 0, time.FixedZone("", 6*3600))
+
+	days, err := leadDaysUntil("2024-05-04", today)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, days)
+
+	days, err = leadDaysUntil("2024-04-30", today)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, days)
+
+	_, err = leadDaysUntil("May 4", today)
+	assert.Error(t, err)
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"travel_advisor/domain"
+)
+
+// explanationRule describes when a factor difference is noticeable and how severe it is
+type explanationRule struct {
+	unit string
+	// same is the largest difference still reported as no change
+	same float64
+	// moderate and high are the magnitudes where severity steps up
+	moderate float64
+	high     float64
+}
+
+var explanationRules = map[string]explanationRule{
+	domain.FactorTemperature:   {unit: "°C", same: 0.5, moderate: 2, high: 5},
+	domain.FactorPM25:          {unit: "µg/m³", same: 2, moderate: 10, high: 25},
+	domain.FactorHeatIndex:     {unit: "°C", same: 1, moderate: 3, high: 6},
+	domain.FactorPrecipitation: {unit: "%", same: 40, moderate: 55, high: 70},
+	domain.FactorWind:          {unit: "km/h", same: 30, moderate: 40, high: 50},
+	domain.FactorRain:          {unit: "mm", same: 5, moderate: 10, high: 25},
+	domain.FactorUV:            {unit: "", same: 6, moderate: 8, high: 11},
+}
+
+// BuildExplanation compares every factor available for both places. Relative
+// factors are reported as differences; rain, wind and UV only matter at the
+// destination and are reported as worse once they pass their threshold.
+func BuildExplanation(origin, dest *domain.TravelConditions) []domain.ReasonItem {
+	items := []domain.ReasonItem{
+		relativeReason(domain.FactorTemperature, dest.Temp2PM-origin.Temp2PM),
+		relativeReason(domain.FactorPM25, dest.PM25-origin.PM25),
+	}
+	if origin.HeatIndex2PM != nil && dest.HeatIndex2PM != nil {
+		items = append(items, relativeReason(domain.FactorHeatIndex, *dest.HeatIndex2PM-*origin.HeatIndex2PM))
+	}
+	if dest.PrecipitationProbability != nil {
+		items = append(items, absoluteReason(domain.FactorPrecipitation, *dest.PrecipitationProbability))
+	}
+	if dest.PrecipitationSum != nil {
+		items = append(items, absoluteReason(domain.FactorRain, *dest.PrecipitationSum))
+	}
+	if dest.WindSpeed2PM != nil {
+		items = append(items, absoluteReason(domain.FactorWind, *dest.WindSpeed2PM))
+	}
+	if dest.UVIndexMax != nil {
+		items = append(items, absoluteReason(domain.FactorUV, *dest.UVIndexMax))
+	}
+	return items
+}
+
+func relativeReason(factor string, diff float64) domain.ReasonItem {
+	rule := explanationRules[factor]
+	item := domain.ReasonItem{
+		Factor:    factor,
+		Direction: domain.DirectionSame,
+		Magnitude: round1(math.Abs(diff)),
+		Unit:      rule.unit,
+		Severity:  severity(math.Abs(diff), rule),
+	}
+	switch {
+	case diff < -rule.same:
+		item.Direction = domain.DirectionBetter
+	case diff > rule.same:
+		item.Direction = domain.DirectionWorse
+	default:
+		item.Severity = domain.SeverityLow
+	}
+	return item
+}
+
+func absoluteReason(factor string, value float64) domain.ReasonItem {
+	rule := explanationRules[factor]
+	item := domain.ReasonItem{
+		Factor:    factor,
+		Direction: domain.DirectionSame,
+		Magnitude: round1(value),
+		Unit:      rule.unit,
+		Severity:  domain.SeverityLow,
+	}
+	if value >= rule.same {
+		item.Direction = domain.DirectionWorse
+		item.Severity = severity(value, rule)
+	}
+	return item
+}
+
+func severity(magnitude float64, rule explanationRule) string {
+	switch {
+	case magnitude >= rule.high:
+		return domain.SeverityHigh
+	case magnitude >= rule.moderate:
+		return domain.SeverityModerate
+	default:
+		return domain.SeverityLow
+	}
+}
+
+// RenderExplanation turns the reason items into a sentence, listing what is
+// better first and contrasting it with what is worse
+func RenderExplanation(verdict string, items []domain.ReasonItem) string {
+	var better, worse []string
+	for _, it := range items {
+		switch it.Direction {
+		case domain.DirectionBetter:
+			better = append(better, reasonClause(it))
+		case domain.DirectionWorse:
+			worse = append(worse, reasonClause(it))
+		}
+	}
+
+	var sentence string
+	switch {
+	case len(better) == 0 && len(worse) == 0:
+		sentence = "Conditions at your destination are similar to where you are."
+	case len(better) == 0:
+		sentence = openClause(worse[0]) + joinClauses(worse[1:], " and ") + "."
+	case len(worse) == 0:
+		sentence = openClause(better[0]) + joinClauses(better[1:], " and ") + "."
+	default:
+		sentence = openClause(better[0]) + joinClauses(better[1:], " and ") +
+			" but " + strings.Join(worse, " and ") + "."
+	}
+
+	switch verdict {
+	case domain.VerdictRecommended:
+		return sentence + " Enjoy your trip!"
+	case domain.VerdictAcceptable:
+		return sentence + " It is an acceptable choice."
+	default:
+		return sentence + " It’s better to stay where you are."
+	}
+}
+
+func reasonClause(it domain.ReasonItem) string {
+	m := strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.1f", it.Magnitude), "0"), ".")
+	better := it.Direction == domain.DirectionBetter
+
+	switch it.Factor {
+	case domain.FactorTemperature:
+		if better {
+			return fmt.Sprintf("it is %s%s cooler", m, it.Unit)
+		}
+		return fmt.Sprintf("it is %s%s hotter", m, it.Unit)
+	case domain.FactorPM25:
+		if better {
+			return fmt.Sprintf("PM2.5 is %s %s lower", m, it.Unit)
+		}
+		return fmt.Sprintf("PM2.5 is %s %s higher", m, it.Unit)
+	case domain.FactorHeatIndex:
+		if better {
+			return fmt.Sprintf("it feels %s%s less muggy", m, it.Unit)
+		}
+		return fmt.Sprintf("it feels %s%s more muggy", m, it.Unit)
+	case domain.FactorPrecipitation:
+		return fmt.Sprintf("there is a %s%s chance of rain", m, it.Unit)
+	case domain.FactorWind:
+		return fmt.Sprintf("winds reach %s %s", m, it.Unit)
+	case domain.FactorRain:
+		return fmt.Sprintf("%s %s of rain is expected", m, it.Unit)
+	case domain.FactorUV:
+		return fmt.Sprintf("the UV index reaches %s", m)
+	default:
+		return it.Factor
+	}
+}
+
+// openClause starts the sentence, naming the destination as the subject
+func openClause(clause string) string {
+	if rest, ok := strings.CutPrefix(clause, "it "); ok {
+		return "Your destination " + rest
+	}
+	return "At your destination " + clause
+}
+
+func joinClauses(clauses []string, sep string) string {
+	if len(clauses) == 0 {
+		return ""
+	}
+	return sep + strings.Join(clauses, sep)
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"testing"
+	"travel_advisor/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildExplanation(t *testing.T) {
+	rain := 75.0
+	origin := &domain.TravelConditions{Temp2PM: 31.4, PM25: 42}
+	dest := &domain.TravelConditions{Temp2PM: 29.3, PM25: 60, PrecipitationProbability: &rain}
+
+	items := BuildExplanation(origin, dest)
+
+	assert.Equal(t, []domain.ReasonItem{
+		{Factor: domain.FactorTemperature, Direction: domain.DirectionBetter, Magnitude: 2.1, Unit: "°C", Severity: domain.SeverityModerate},
+		{Factor: domain.FactorPM25, Direction: domain.DirectionWorse, Magnitude: 18, Unit: "µg/m³", Severity: domain.SeverityModerate},
+		{Factor: domain.FactorPrecipitation, Direction: domain.DirectionWorse, Magnitude: 75, Unit: "%", Severity: domain.SeverityHigh},
+	}, items)
+}
+
+func TestRenderExplanation(t *testing.T) {
+	tests := []struct {
+		name     string
+		verdict  string
+		items    []domain.ReasonItem
+		expected string
+	}{
+		{
+			name:    "Mixed outcome",
+			verdict: domain.VerdictAcceptable,
+			items: []domain.ReasonItem{
+				{Factor: domain.FactorTemperature, Direction: domain.DirectionBetter, Magnitude: 2.1, Unit: "°C"},
+				{Factor: domain.FactorPM25, Direction: domain.DirectionWorse, Magnitude: 18, Unit: "µg/m³"},
+			},
+			expected: "Your destination is 2.1°C cooler but PM2.5 is 18 µg/m³ higher. It is an acceptable choice.",
+		},
+		{
+			name:    "Only air quality is better",
+			verdict: domain.VerdictNotRecommended,
+			items: []domain.ReasonItem{
+				{Factor: domain.FactorTemperature, Direction: domain.DirectionWorse, Magnitude: 3.5, Unit: "°C"},
+				{Factor: domain.FactorPM25, Direction: domain.DirectionBetter, Magnitude: 5, Unit: "µg/m³"},
+			},
+			expected: "At your destination PM2.5 is 5 µg/m³ lower but it is 3.5°C hotter. It’s better to stay where you are.",
+		},
+		{
+			name:    "Everything better",
+			verdict: domain.VerdictRecommended,
+			items: []domain.ReasonItem{
+				{Factor: domain.FactorTemperature, Direction: domain.DirectionBetter, Magnitude: 2, Unit: "°C"},
+				{Factor: domain.FactorPM25, Direction: domain.DirectionBetter, Magnitude: 5, Unit: "µg/m³"},
+			},
+			expected: "Your destination is 2°C cooler and PM2.5 is 5 µg/m³ lower. Enjoy your trip!",
+		},
+		{
+			name:    "No noticeable difference",
+			verdict: domain.VerdictAcceptable,
+			items: []domain.ReasonItem{
+				{Factor: domain.FactorTemperature, Direction: domain.DirectionSame, Magnitude: 0.2, Unit: "°C"},
+			},
+			expected: "Conditions at your destination are similar to where you are. It is an acceptable choice.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RenderExplanation(tt.verdict, tt.items))
+		})
+	}
+}
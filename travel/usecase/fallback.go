@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/conn"
+	"travel_advisor/pkg/geo"
+	"travel_advisor/pkg/log"
+)
+
+const (
+	// defaultStaleAfter is used when config.yml leaves the staleness unset,
+	// a little over the hourly refresh of the scheduler
+	defaultStaleAfter = 90 * time.Minute
+
+	// revalidateTimeout bounds a background refresh of a single district
+	revalidateTimeout = 2 * time.Minute
+
+	// revalidateCooldown is how long a district whose refresh failed is left
+	// alone, so an upstream outage is not hit by every stale read
+	revalidateCooldown = 5 * time.Minute
+)
+
+var errNoCachedFallback = errors.New("no cached data for the origin or the destination")
+
+func staleAfter() time.Duration {
+	if m := config.Scheduler().StaleAfterMinutes; m > 0 {
+		return time.Duration(m) * time.Minute
+	}
+	return defaultStaleAfter
+}
+
+// staleRecommendation compares the cached entries of the destination and of
+// the cached district nearest the origin. It stands in for the live
+// recommendation when the forecasts can not be fetched.
+func (t *TravelUsecase) staleRecommendation(
+	ctx context.Context,
+	req domain.TravelRecommendationRequest,
+	destDistrict *domain.District,
+	cfg config.RecommendationCfg,
+) (*domain.TravelRecommendationResponse, error) {
+
+	// the cache holds the outlook of the forecast horizon, it says nothing
+	// about dates outside of it
+	if err := ValidateDateWindow(req.TravelDate, req.TravelDate, today()); err != nil {
+		return nil, err
+	}
+
+	cached, err := t.cachedDistricts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	all, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{})
+	if err != nil {
+		return nil, err
+	}
+	origin, dest := FallbackEntries(cached, all, destDistrict.Name, req.CurrentLat, req.CurrentLong)
+	if origin == nil || dest == nil {
+		return nil, errNoCachedFallback
+	}
+
+	current := origin.Conditions()
+	normals := t.climateNormals(ctx, req.TravelDate)
+	resp := t.buildRecommendation(destDistrict, current, dest.Conditions(), normals[req.TravelDate][destDistrict.ID], cfg)
+	resp.Stale = true
+	resp.DataAgeSeconds = dataAge(time.Now(), origin, dest)
+	resp.Reason += fmt.Sprintf(" Live forecasts are unavailable, this compares the cached outlook of %s, the district nearest to you.",
+		origin.Name)
+	if resp.Recommendation == domain.VerdictNotRecommended {
+		resp.Alternatives = t.alternatives(ctx, destDistrict, req.CurrentLat, req.CurrentLong, current)
+	}
+	return resp, nil
+}
+
+// FallbackEntries picks the cached entry of the destination and the cached
+// entry of the district nearest to lat/long. Either is nil when not cached.
+func FallbackEntries(
+	cached []domain.DistrictCache,
+	districts []*domain.District,
+	destName string,
+	lat, long float64,
+) (origin, dest *domain.DistrictCache) {
+
+	byName := make(map[string]*domain.District, len(districts))
+	for _, d := range districts {
+		byName[d.Name] = d
+	}
+
+	nearest := -1.0
+	for i := range cached {
+		c := &cached[i]
+		if c.Name == destName {
+			dest = c
+		}
+		d, ok := byName[c.Name]
+		if !ok {
+			continue
+		}
+		if dist := geo.Distance(lat, long, d.Lat, d.Long); nearest < 0 || dist < nearest {
+			origin, nearest = c, dist
+		}
+	}
+	return origin, dest
+}
+
+// dataAge is the age in seconds of the oldest entry, nil when any age is unknown
+func dataAge(now time.Time, entries ...*domain.DistrictCache) *int64 {
+	var oldest time.Duration
+	for _, e := range entries {
+		age, ok := e.Age(now)
+		if !ok {
+			return nil
+		}
+		oldest = max(oldest, age)
+	}
+	secs := int64(oldest / time.Second)
+	return &secs
+}
+
+// StaleDistricts names the entries fetched more than staleAfter before now.
+// Entries of unknown age are left to the scheduler.
+func StaleDistricts(entries []domain.DistrictCache, now time.Time, staleAfter time.Duration) []string {
+	var names []string
+	for _, e := range entries {
+		if age, ok := e.Age(now); ok && age > staleAfter {
+			names = append(names, e.Name)
+		}
+	}
+	return names
+}
+
+// revalidate refreshes the named cache entries in the background, one
+// refresh per district at a time. The reads that found them stale are
+// served the old entries meanwhile. A failed refresh is not retried before
+// revalidateCooldown has passed.
+func (t *TravelUsecase) revalidate(names []string) {
+	for _, name := range names {
+		if !t.claimRevalidation(name, time.Now()) {
+			continue
+		}
+		go func(name string) {
+			ctx, cancel := context.WithTimeout(context.Background(), revalidateTimeout)
+			defer cancel()
+			if err := t.refreshDistrict(ctx, name); err != nil {
+				log.Warn("district cache refresh failed ", name, err)
+				t.revalidating.Store(name, time.Now().Add(revalidateCooldown))
+				return
+			}
+			t.revalidating.Delete(name)
+		}(name)
+	}
+}
+
+// claimRevalidation marks the district as being refreshed. It fails while
+// another refresh runs, the zero time, or before the retry-after time a
+// failed refresh left behind.
+func (t *TravelUsecase) claimRevalidation(name string, now time.Time) bool {
+	v, loaded := t.revalidating.LoadOrStore(name, time.Time{})
+	if !loaded {
+		return true
+	}
+	retryAfter := v.(time.Time)
+	if retryAfter.IsZero() || now.Before(retryAfter) {
+		return false
+	}
+	return t.revalidating.CompareAndSwap(name, v, time.Time{})
+}
+
+func (t *TravelUsecase) refreshDistrict(ctx context.Context, name string) error {
+	districts, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{DistrictName: &name})
+	if err != nil {
+		return err
+	}
+	if len(districts) == 0 {
+		return domain.ErrDistrictNotFound
+	}
+	agg, err := helpers.NewAggregation(config.Aggregation())
+	if err != nil {
+		return err
+	}
+
+	entry, err := helpers.FetchDistrictCache(ctx, conn.GetHTTClient(), districts[0], agg, time.Now())
+	if err != nil {
+		return err
+	}
+	return helpers.StoreDistrictCache(ctx, t.CacheRepository, entry)
+}
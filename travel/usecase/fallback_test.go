@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+	"travel_advisor/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTravelUsecase_RecommendTravel_CachedFallback(t *testing.T) {
+	now := time.Now()
+	dhaka := &domain.District{ID: 47, Name: "Dhaka", Lat: 23.8103, Long: 90.4125}
+	sylhet := &domain.District{ID: 36, Name: "Sylhet", Lat: 24.8949, Long: 91.8687}
+	entries := map[string]domain.DistrictCache{
+		"Dhaka":  {Version: domain.DistrictCacheVersion, Name: "Dhaka", UpdatedAt: now.Add(-20 * time.Minute), Temp: 33, PM25: 80},
+		"Sylhet": {Version: domain.DistrictCacheVersion, Name: "Sylhet", UpdatedAt: now.Add(-5 * time.Minute), Temp: 29, PM25: 40},
+	}
+
+	mockCache := new(MockCache)
+	mockCache.On("Keys", mock.Anything).Return([]string{"Dhaka", "Sylhet"}, nil)
+	for name, e := range entries {
+		data, _ := json.Marshal(e)
+		mockCache.On("Get", mock.Anything, name).Return(string(data), nil)
+	}
+	mockDistrictRepo := new(MockDistrictRepository)
+	mockDistrictRepo.On("List", mock.Anything, &domain.DistrictCriteria{DistrictName: stringPtr("Sylhet")}).
+		Return([]*domain.District{sylhet}, nil)
+	mockDistrictRepo.On("List", mock.Anything, &domain.DistrictCriteria{}).
+		Return([]*domain.District{dhaka, sylhet}, nil)
+
+	// a cancelled request fails the live fetches without reaching the network
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := NewTravelUsecase(mockCache, mockDistrictRepo, nil).RecommendTravel(ctx, domain.TravelRecommendationRequest{
+		CurrentLat:          23.78,
+		CurrentLong:         90.40,
+		DestinationDistrict: "Sylhet",
+		TravelDate:          today().Format(time.DateOnly),
+	})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, result) {
+		assert.True(t, result.Stale)
+		assert.Equal(t, -4.0, result.TempDiff, "compared against Dhaka, nearest to the origin")
+		assert.Equal(t, -40.0, result.PM25Diff)
+		if assert.NotNil(t, result.DataAgeSeconds) {
+			assert.InDelta(t, 20*60, *result.DataAgeSeconds, 5, "age of the older entry")
+		}
+		assert.Contains(t, result.Reason, "cached outlook of Dhaka")
+	}
+}
+
+func TestTravelUsecase_RecommendTravel_NoFallbackOutsideHorizon(t *testing.T) {
+	mockDistrictRepo := new(MockDistrictRepository)
+	mockDistrictRepo.On("List", mock.Anything, mock.Anything).
+		Return([]*domain.District{{ID: 36, Name: "Sylhet", Lat: 24.8949, Long: 91.8687}}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewTravelUsecase(new(MockCache), mockDistrictRepo, nil).RecommendTravel(ctx, domain.TravelRecommendationRequest{
+		DestinationDistrict: "Sylhet",
+		TravelDate:          "2024-01-15",
+	})
+
+	assert.ErrorIs(t, err, context.Canceled, "the live fetch error is returned")
+}
+
+func TestFallbackEntries(t *testing.T) {
+	cached := []domain.DistrictCache{{Name: "Sylhet"}, {Name: "Gazipur"}, {Name: "Chattogram"}}
+	districts := []*domain.District{
+		{Name: "Sylhet", Lat: 24.8949, Long: 91.8687},
+		{Name: "Gazipur", Lat: 24.0023, Long: 90.4264},
+		{Name: "Chattogram", Lat: 22.3569, Long: 91.7832},
+		{Name: "Dhaka", Lat: 23.8103, Long: 90.4125},
+	}
+
+	origin, dest := FallbackEntries(cached, districts, "Sylhet", 23.81, 90.41)
+	assert.Equal(t, "Gazipur", origin.Name, "Dhaka is nearer but not cached")
+	assert.Equal(t, "Sylhet", dest.Name)
+
+	_, dest = FallbackEntries(cached, districts, "Dhaka", 23.81, 90.41)
+	assert.Nil(t, dest)
+}
+
+func TestStaleDistricts(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	entries := []domain.DistrictCache{
+		{Name: "Sylhet", UpdatedAt: now.Add(-2 * time.Hour)},
+		{Name: "Dhaka", UpdatedAt: now.Add(-10 * time.Minute)},
+		{Name: "Khulna"},
+	}
+
+	assert.Equal(t, []string{"Sylhet"}, StaleDistricts(entries, now, 90*time.Minute))
+}
+
+func TestTravelUsecase_ClaimRevalidation(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	usecase := &TravelUsecase{}
+
+	assert.True(t, usecase.claimRevalidation("Sylhet", now))
+	assert.False(t, usecase.claimRevalidation("Sylhet", now), "refresh in flight")
+
+	// the refresh failed
+	usecase.revalidating.Store("Sylhet", now.Add(revalidateCooldown))
+	assert.False(t, usecase.claimRevalidation("Sylhet", now.Add(time.Minute)), "cooling down")
+	assert.True(t, usecase.claimRevalidation("Sylhet", now.Add(revalidateCooldown)))
+	assert.False(t, usecase.claimRevalidation("Sylhet", now.Add(revalidateCooldown)), "retry in flight")
+
+	// the refresh succeeded
+	usecase.revalidating.Delete("Sylhet")
+	assert.True(t, usecase.claimRevalidation("Sylhet", now))
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/conn"
+)
+
+const (
+	defaultWindowHours      = 3
+	defaultTravelHoursStart = 6
+	defaultTravelHoursEnd   = 22
+
+	// comfortableTemp is the feels like temperature with no heat discomfort,
+	// maxHeatExcess the excess over it rated as the worst heat
+	comfortableTemp = 24.0
+	maxHeatExcess   = 16.0
+	// maxPM25 is the PM2.5 concentration rated as the worst pollution
+	maxPM25 = 150.0
+)
+
+// HourlyForecast returns the hourly series of a district on date, defaulting
+// to today, with the most comfortable departure and arrival windows
+func (t *TravelUsecase) HourlyForecast(
+	ctx context.Context,
+	districtID int64,
+	date string,
+) (*domain.HourlyForecastResponse, error) {
+
+	if date == "" {
+		date = today().Format(time.DateOnly)
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return nil, domain.ErrInvalidTravelDate
+	}
+
+	districts, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{ID: &districtID})
+	if err != nil {
+		return nil, err
+	}
+	if len(districts) == 0 {
+		return nil, domain.ErrDistrictNotFound
+	}
+	district := districts[0]
+
+	hours, err := helpers.FetchHourlyConditions(ctx, conn.GetHTTClient(), district.Lat, district.Long, date)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := config.Recommendation()
+	for _, h := range hours {
+		h.Score = HourDiscomfort(h, cfg.Weights)
+	}
+
+	length, start, end := cfg.WindowHours, cfg.TravelHoursStart, cfg.TravelHoursEnd
+	if length <= 0 {
+		length = defaultWindowHours
+	}
+	if end <= start {
+		start, end = defaultTravelHoursStart, defaultTravelHoursEnd
+	}
+
+	resp := &domain.HourlyForecastResponse{
+		DistrictID: district.ID,
+		District:   district.Name,
+		Date:       date,
+		Hours:      hours,
+	}
+	resp.DepartureWindow = BestWindow(hours, length, start, end, time.Time{})
+	if resp.DepartureWindow != nil {
+		resp.ArrivalWindow = BestWindow(hours, length, start, end, resp.DepartureWindow.End)
+	}
+	return resp, nil
+}
+
+// HourDiscomfort rates the heat and pollution of an hour from 0 (comfortable)
+// to 100, weighing heat by the temperature and heat index weights and
+// pollution by the PM2.5 weight. It is nil when either reading is missing.
+func HourDiscomfort(h *domain.HourlyCondition, w config.ScoringWeights) *float64 {
+	if h.Temperature == nil || h.PM25 == nil {
+		return nil
+	}
+
+	feelsLike := *h.Temperature
+	if h.HeatIndex != nil {
+		feelsLike = *h.HeatIndex
+	}
+	heat := clamp((feelsLike - comfortableTemp) / maxHeatExcess * 100)
+	pollution := clamp(*h.PM25 / maxPM25 * 100)
+
+	heatWeight, pollutionWeight := w.Temperature+w.HeatIndex, w.PM25
+	if heatWeight+pollutionWeight <= 0 {
+		heatWeight, pollutionWeight = 1, 1
+	}
+	score := round1((heat*heatWeight + pollution*pollutionWeight) / (heatWeight + pollutionWeight))
+	return &score
+}
+
+// BestWindow finds the run of length contiguous scored hours with the lowest
+// mean score, starting at or after notBefore and lying within the local hours
+// [startHour, endHour). The earliest run wins ties; nil when none fits.
+func BestWindow(hours []*domain.HourlyCondition, length, startHour, endHour int, notBefore time.Time) *domain.TimeWindow {
+	var best *domain.TimeWindow
+
+	for i := 0; i+length <= len(hours); i++ {
+		first := hours[i]
+		if first.Time.Before(notBefore) {
+			continue
+		}
+
+		var sum float64
+		fits := true
+		for j := i; j < i+length; j++ {
+			h := hours[j]
+			contiguous := h.Time.Equal(first.Time.Add(time.Duration(j-i) * time.Hour))
+			if !contiguous || h.Score == nil || h.Time.Hour() < startHour || h.Time.Hour() >= endHour {
+				fits = false
+				break
+			}
+			sum += *h.Score
+		}
+		if !fits {
+			continue
+		}
+
+		score := round1(sum / float64(length))
+		if best == nil || score < best.Score {
+			best = &domain.TimeWindow{
+				Start: first.Time,
+				End:   first.Time.Add(time.Duration(length) * time.Hour),
+				Score: score,
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,373 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/conn"
+	"travel_advisor/pkg/geo"
+	"travel_advisor/pkg/log"
+)
+
+// defaultMaxItineraryStops is used when config.yml leaves the cap unset
+const defaultMaxItineraryStops = 10
+
+// Itinerary plans one district per day. Optional districts fill the days the
+// must visit ones leave free, the route is a nearest neighbour tour improved
+// with 2-opt, and each stop then gets its most comfortable day in route order.
+func (t *TravelUsecase) Itinerary(ctx context.Context, req domain.ItineraryRequest) (*domain.ItineraryResponse, error) {
+	if err := ValidateDateWindow(req.DateFrom, req.DateTo, today()); err != nil {
+		return nil, err
+	}
+	days := datesBetween(req.DateFrom, req.DateTo)
+
+	must := uniqueNames(req.MustVisit)
+	mustSet := make(map[string]bool, len(must))
+	for _, n := range must {
+		mustSet[n] = true
+	}
+	var optional []string
+	for _, n := range uniqueNames(req.Optional) {
+		if !mustSet[n] {
+			optional = append(optional, n)
+		}
+	}
+	maxStops := config.Recommendation().MaxItineraryStops
+	if maxStops <= 0 {
+		maxStops = defaultMaxItineraryStops
+	}
+	switch {
+	case len(must)+len(optional) == 0:
+		return nil, domain.ErrNoStops
+	case len(must)+len(optional) > maxStops:
+		return nil, fmt.Errorf("%w: at most %d allowed", domain.ErrTooManyCandidates, maxStops)
+	case len(must) > len(days):
+		return nil, fmt.Errorf("%w: %d districts in %d days", domain.ErrTooManyStops, len(must), len(days))
+	}
+
+	all, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{})
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.District, len(all))
+	for _, d := range all {
+		byName[d.Name] = d
+	}
+	candidates := make([]*domain.District, 0, len(must)+len(optional))
+	for _, name := range append(append([]string{}, must...), optional...) {
+		d, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrDestinationNotFound, name)
+		}
+		candidates = append(candidates, d)
+	}
+
+	origin, forecasts, cached, err := t.itineraryForecasts(ctx, req, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := config.Recommendation()
+	scoreDays := func(d *domain.District) ([]float64, []*domain.TravelScore) {
+		values := make([]float64, len(days))
+		scores := make([]*domain.TravelScore, len(days))
+		for i, day := range days {
+			o, dest := origin[day], forecasts[d.Name][day]
+			if o == nil || dest == nil {
+				continue
+			}
+			scores[i] = ScoreTravel(o, dest, rec)
+			values[i] = scores[i].Score
+		}
+		return values, scores
+	}
+
+	// optional districts with the best day first fill the free days
+	selected := append([]*domain.District{}, candidates[:len(must)]...)
+	rest := candidates[len(must):]
+	best := make(map[string]float64, len(rest))
+	for _, d := range rest {
+		values, _ := scoreDays(d)
+		for _, v := range values {
+			best[d.Name] = math.Max(best[d.Name], v)
+		}
+	}
+	sort.SliceStable(rest, func(i, j int) bool { return best[rest[i].Name] > best[rest[j].Name] })
+	free := len(days) - len(must)
+	if free > len(rest) {
+		free = len(rest)
+	}
+	selected = append(selected, rest[:free]...)
+
+	resp := &domain.ItineraryResponse{
+		Start:    req.Start,
+		DateFrom: req.DateFrom,
+		DateTo:   req.DateTo,
+	}
+	for _, d := range rest[free:] {
+		resp.Skipped = append(resp.Skipped, d.Name)
+	}
+
+	route := PlanRoute(req.Start, selected)
+	values := make([][]float64, len(route))
+	scores := make([][]*domain.TravelScore, len(route))
+	for i, d := range route {
+		values[i], scores[i] = scoreDays(d)
+	}
+	assigned := AssignDays(values)
+
+	prevLat, prevLong := req.Start.Lat, req.Start.Long
+	for i, d := range route {
+		day := days[assigned[i]]
+		km := round1(geo.Distance(prevLat, prevLong, d.Lat, d.Long))
+		prevLat, prevLong = d.Lat, d.Long
+
+		stop := domain.ItineraryStop{
+			Order:      i + 1,
+			Date:       day,
+			District:   d.Name,
+			MustVisit:  mustSet[d.Name],
+			DistanceKm: km,
+			Conditions: forecasts[d.Name][day],
+			Cached:     cached[d.Name],
+		}
+		if s := scores[i][assigned[i]]; s != nil {
+			stop.Score = s.Score
+			stop.Recommendation = s.Verdict
+		}
+		resp.Stops = append(resp.Stops, stop)
+		resp.TotalDistanceKm += km
+	}
+	resp.TotalDistanceKm = round1(resp.TotalDistanceKm)
+
+	return resp, nil
+}
+
+// itineraryForecasts returns the daily conditions of the start and every
+// candidate. Candidates take the daily forecasts the scheduler cached while
+// those are fresh and cover the window, the others are fetched live. A live
+// fetch that fails falls back to the cached entry for every day.
+func (t *TravelUsecase) itineraryForecasts(
+	ctx context.Context,
+	req domain.ItineraryRequest,
+	candidates []*domain.District,
+) (map[string]*domain.TravelConditions, map[string]map[string]*domain.TravelConditions, map[string]bool, error) {
+
+	days := datesBetween(req.DateFrom, req.DateTo)
+	list, err := t.cachedDistricts(ctx)
+	if err != nil {
+		log.Warn("district cache read failed ", err)
+	}
+	cache := make(map[string]domain.DistrictCache, len(list))
+	for _, c := range list {
+		cache[c.Name] = c
+	}
+
+	forecasts := make(map[string]map[string]*domain.TravelConditions, len(candidates))
+	var fromCache, live []*domain.District
+	for _, d := range candidates {
+		if daily, ok := CachedDaily(cache[d.Name], days, time.Now(), staleAfter()); ok {
+			forecasts[d.Name] = daily
+			fromCache = append(fromCache, d)
+			continue
+		}
+		live = append(live, d)
+	}
+
+	client := conn.GetHTTClient()
+	sun := scoresUV(config.Recommendation())
+
+	var (
+		origin    map[string]*domain.TravelConditions
+		errOrigin error
+		conds     = make([]map[string]*domain.TravelConditions, len(live))
+		errs      = make([]error, len(live))
+		wg        sync.WaitGroup
+	)
+
+	wg.Add(1 + len(live))
+	go func() {
+		defer wg.Done()
+		origin, errOrigin = helpers.FetchDailyTravelConditions(ctx, client, req.Start.Lat, req.Start.Long, req.DateFrom, req.DateTo, false)
+	}()
+	for i, d := range live {
+		i, d := i, d
+		go func() {
+			defer wg.Done()
+			conds[i], errs[i] = helpers.FetchDailyTravelConditions(ctx, client, d.Lat, d.Long, req.DateFrom, req.DateTo, sun)
+		}()
+	}
+	if sun {
+		// the scheduler does not cache the UV index
+		wg.Add(len(fromCache))
+		for _, d := range fromCache {
+			d, daily := d, forecasts[d.Name]
+			go func() {
+				defer wg.Done()
+				s, err := helpers.FetchDailySun(ctx, client, d.Lat, d.Long, req.DateFrom, req.DateTo)
+				if err != nil {
+					log.Warn("daily sun fetch failed ", d.Name, err)
+					return
+				}
+				helpers.ApplyDailySun(daily, s)
+			}()
+		}
+	}
+	wg.Wait()
+
+	if errOrigin != nil {
+		return nil, nil, nil, errOrigin
+	}
+
+	cached := make(map[string]bool)
+	for i, d := range live {
+		if errs[i] == nil {
+			forecasts[d.Name] = conds[i]
+			continue
+		}
+
+		c, ok := cache[d.Name]
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("%s: %v", d.Name, errs[i])
+		}
+		log.Warn("itinerary forecast failed, using cached conditions for ", d.Name, ": ", errs[i])
+
+		forecasts[d.Name] = make(map[string]*domain.TravelConditions, len(days))
+		for _, day := range days {
+			if dc, ok := c.Daily[day]; ok {
+				forecasts[d.Name][day] = dc
+			} else {
+				forecasts[d.Name][day] = c.Conditions()
+			}
+		}
+		cached[d.Name] = true
+	}
+	return origin, forecasts, cached, nil
+}
+
+// CachedDaily returns the cached conditions of each of days, false when the
+// entry is older than staleAfter or misses any of them
+func CachedDaily(
+	entry domain.DistrictCache,
+	days []string,
+	now time.Time,
+	staleAfter time.Duration,
+) (map[string]*domain.TravelConditions, bool) {
+
+	if age, ok := entry.Age(now); !ok || age > staleAfter {
+		return nil, false
+	}
+	daily := make(map[string]*domain.TravelConditions, len(days))
+	for _, day := range days {
+		c, ok := entry.Daily[day]
+		if !ok {
+			return nil, false
+		}
+		daily[day] = c
+	}
+	return daily, true
+}
+
+// PlanRoute orders the stops into a short open path from start: nearest
+// neighbour first, then 2-opt segment reversals until nothing improves
+func PlanRoute(start domain.Coordinate, stops []*domain.District) []*domain.District {
+	route := make([]*domain.District, 0, len(stops))
+	left := append([]*domain.District{}, stops...)
+	lat, long := start.Lat, start.Long
+	for len(left) > 0 {
+		next := 0
+		for i, d := range left {
+			if geo.Distance(lat, long, d.Lat, d.Long) < geo.Distance(lat, long, left[next].Lat, left[next].Long) {
+				next = i
+			}
+		}
+		route = append(route, left[next])
+		lat, long = left[next].Lat, left[next].Long
+		left = append(left[:next], left[next+1:]...)
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(route)-1; i++ {
+			for j := i + 1; j < len(route); j++ {
+				candidate := append([]*domain.District{}, route...)
+				for a, b := i, j; a < b; a, b = a+1, b-1 {
+					candidate[a], candidate[b] = candidate[b], candidate[a]
+				}
+				if routeLength(start, candidate) < routeLength(start, route)-1e-9 {
+					route, improved = candidate, true
+				}
+			}
+		}
+	}
+	return route
+}
+
+func routeLength(start domain.Coordinate, route []*domain.District) float64 {
+	var km float64
+	lat, long := start.Lat, start.Long
+	for _, d := range route {
+		km += geo.Distance(lat, long, d.Lat, d.Long)
+		lat, long = d.Lat, d.Long
+	}
+	return km
+}
+
+// AssignDays gives every stop, in route order, a strictly later day than the
+// one before it so the sum of the scores is the highest. scores[stop][day]
+// must have at least as many days as stops.
+func AssignDays(scores [][]float64) []int {
+	stops := len(scores)
+	if stops == 0 {
+		return nil
+	}
+	days := len(scores[0])
+
+	// best[s][d] is the highest total of stops s.. when stop s is on day d or later
+	best := make([][]float64, stops+1)
+	for s := range best {
+		best[s] = make([]float64, days+1)
+		for d := range best[s] {
+			if s < stops && days-d < stops-s {
+				best[s][d] = math.Inf(-1)
+			}
+		}
+	}
+	for s := stops - 1; s >= 0; s-- {
+		for d := days - 1; d >= 0; d-- {
+			best[s][d] = math.Max(best[s][d+1], scores[s][d]+best[s+1][d+1])
+			if days-d < stops-s {
+				best[s][d] = math.Inf(-1)
+			}
+		}
+	}
+
+	assigned := make([]int, stops)
+	d := 0
+	for s := 0; s < stops; s++ {
+		for best[s][d] != scores[s][d]+best[s+1][d+1] {
+			d++
+		}
+		assigned[s] = d
+		d++
+	}
+	return assigned
+}
+
+// datesBetween lists the YYYY-MM-DD dates from from to to, inclusive
+func datesBetween(from, to string) []string {
+	f, _ := time.Parse(time.DateOnly, from)
+	tt, _ := time.Parse(time.DateOnly, to)
+
+	var dates []string
+	for d := f; !d.After(tt); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d.Format(time.DateOnly))
+	}
+	return dates
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
+	"travel_advisor/pkg/conn"
+	"travel_advisor/pkg/geo"
+	"travel_advisor/pkg/log"
+)
+
+// defaultMeetup is used for anything config.yml leaves unset
+var defaultMeetup = config.MeetupCfg{
+	DistanceWeight: 0.4,
+	MaxDistanceKm:  300,
+	Candidates:     8,
+	MaxOrigins:     10,
+	Results:        5,
+}
+
+// Meetup suggests districts every origin can meet at. The districts closest
+// to the group are forecast for the travel date and scored on the comfort
+// they bring each origin and the distance the group travels.
+func (t *TravelUsecase) Meetup(ctx context.Context, req domain.MeetupRequest) (*domain.MeetupResponse, error) {
+	cfg := meetupConfig()
+
+	if req.Objective == "" {
+		req.Objective = domain.MeetupObjectiveTotal
+	}
+	switch {
+	case req.Objective != domain.MeetupObjectiveTotal && req.Objective != domain.MeetupObjectiveMax:
+		return nil, domain.ErrInvalidObjective
+	case len(req.Origins) == 0:
+		return nil, domain.ErrNoOrigins
+	case len(req.Origins) > cfg.MaxOrigins:
+		return nil, fmt.Errorf("%w: at most %d allowed", domain.ErrTooManyOrigins, cfg.MaxOrigins)
+	}
+
+	all, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{})
+	if err != nil {
+		return nil, err
+	}
+	candidates := ShortlistMeetupDistricts(all, req.Origins, req.Objective, cfg.Candidates)
+
+	client := conn.GetHTTClient()
+
+	var (
+		originConds = make([]*domain.TravelConditions, len(req.Origins))
+		originErrs  = make([]error, len(req.Origins))
+		destConds   = make([]*domain.TravelConditions, len(candidates))
+		destErrs    = make([]error, len(candidates))
+		wg          sync.WaitGroup
+	)
+
+	wg.Add(len(req.Origins) + len(candidates))
+	for i, o := range req.Origins {
+		i, o := i, o
+		go func() {
+			defer wg.Done()
+			originConds[i], originErrs[i] = helpers.FetchTravelConditions(ctx, client, o.Lat, o.Long, req.TravelDate, false)
+		}()
+	}
+	for i, d := range candidates {
+		i, d := i, d
+		go func() {
+			defer wg.Done()
+			destConds[i], destErrs[i] = helpers.FetchTravelConditions(ctx, client, d.Lat, d.Long, req.TravelDate, scoresUV(config.Recommendation()))
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(originErrs...); err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]domain.MeetupSuggestion, 0, len(candidates))
+	for i, d := range candidates {
+		if destErrs[i] != nil {
+			log.Warn("meetup candidate skipped ", d.Name, ": ", destErrs[i])
+			continue
+		}
+		suggestions = append(suggestions, ScoreMeetup(d, destConds[i], req.Origins, originConds, req.Objective, cfg))
+	}
+	if len(suggestions) == 0 && len(candidates) > 0 {
+		return nil, fmt.Errorf("no meetup candidate could be forecast: %v", errors.Join(destErrs...))
+	}
+
+	RankMeetupSuggestions(suggestions)
+	if len(suggestions) > cfg.Results {
+		suggestions = suggestions[:cfg.Results]
+	}
+
+	return &domain.MeetupResponse{
+		TravelDate:  req.TravelDate,
+		Objective:   req.Objective,
+		Suggestions: suggestions,
+	}, nil
+}
+
+func meetupConfig() config.MeetupCfg {
+	cfg := config.Meetup()
+	if cfg.DistanceWeight <= 0 || cfg.DistanceWeight >= 1 {
+		cfg.DistanceWeight = defaultMeetup.DistanceWeight
+	}
+	if cfg.MaxDistanceKm <= 0 {
+		cfg.MaxDistanceKm = defaultMeetup.MaxDistanceKm
+	}
+	if cfg.Candidates <= 0 {
+		cfg.Candidates = defaultMeetup.Candidates
+	}
+	if cfg.MaxOrigins <= 0 {
+		cfg.MaxOrigins = defaultMeetup.MaxOrigins
+	}
+	if cfg.Results <= 0 {
+		cfg.Results = defaultMeetup.Results
+	}
+	return cfg
+}
+
+// meetupDistance is the distance the objective minimizes: the mean distance
+// for total, which ranks the same as the sum, or the longest one for max
+func meetupDistance(d *domain.District, origins []domain.Coordinate, objective string) float64 {
+	var sum, longest float64
+	for _, o := range origins {
+		km := geo.Distance(o.Lat, o.Long, d.Lat, d.Long)
+		sum += km
+		longest = math.Max(longest, km)
+	}
+	if objective == domain.MeetupObjectiveMax {
+		return longest
+	}
+	return sum / float64(len(origins))
+}
+
+// ShortlistMeetupDistricts returns the n districts with the shortest objective distance
+func ShortlistMeetupDistricts(districts []*domain.District, origins []domain.Coordinate, objective string, n int) []*domain.District {
+	shortlist := make([]*domain.District, len(districts))
+	copy(shortlist, districts)
+
+	sort.SliceStable(shortlist, func(i, j int) bool {
+		return meetupDistance(shortlist[i], origins, objective) < meetupDistance(shortlist[j], origins, objective)
+	})
+	if len(shortlist) > n {
+		shortlist = shortlist[:n]
+	}
+	return shortlist
+}
+
+// ScoreMeetup blends the mean travel score of the district across the origins
+// with a distance score that drops to zero at the configured maximum distance
+func ScoreMeetup(
+	d *domain.District,
+	dest *domain.TravelConditions,
+	origins []domain.Coordinate,
+	originConds []*domain.TravelConditions,
+	objective string,
+	cfg config.MeetupCfg,
+) domain.MeetupSuggestion {
+
+	s := domain.MeetupSuggestion{
+		District:   d.Name,
+		Conditions: dest,
+		Origins:    make([]domain.MeetupOrigin, 0, len(origins)),
+	}
+
+	var comfort float64
+	for i, o := range origins {
+		km := geo.Distance(o.Lat, o.Long, d.Lat, d.Long)
+		score := ScoreTravel(originConds[i], dest, config.Recommendation())
+
+		s.TotalDistanceKm += km
+		s.MaxDistanceKm = math.Max(s.MaxDistanceKm, km)
+		comfort += score.Score
+
+		s.Origins = append(s.Origins, domain.MeetupOrigin{
+			Origin:         o,
+			DistanceKm:     round1(km),
+			TempDiff:       dest.Temp2PM - originConds[i].Temp2PM,
+			PM25Diff:       dest.PM25 - originConds[i].PM25,
+			Score:          score.Score,
+			Recommendation: score.Verdict,
+		})
+	}
+
+	s.ComfortScore = round1(comfort / float64(len(origins)))
+	s.DistanceScore = round1(clamp(100 - meetupDistance(d, origins, objective)/cfg.MaxDistanceKm*100))
+	s.Score = round1((1-cfg.DistanceWeight)*s.ComfortScore + cfg.DistanceWeight*s.DistanceScore)
+	s.TotalDistanceKm = round1(s.TotalDistanceKm)
+	s.MaxDistanceKm = round1(s.MaxDistanceKm)
+	return s
+}
+
+// RankMeetupSuggestions orders by score, breaking ties on the shorter total distance
+func RankMeetupSuggestions(suggestions []domain.MeetupSuggestion) {
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score == suggestions[j].Score {
+			return suggestions[i].TotalDistanceKm < suggestions[j].TotalDistanceKm
+		}
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	for i := range suggestions {
+		suggestions[i].Rank = i + 1
+	}
+}
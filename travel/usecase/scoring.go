@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/config"
+)
+
+// defaultRecommendation is used for anything config.yml leaves unset
+var defaultRecommendation = config.RecommendationCfg{
+	Weights: config.ScoringWeights{
+		Temperature:   0.35,
+		PM25:          0.30,
+		HeatIndex:     0.15,
+		Precipitation: 0.10,
+		Wind:          0.10,
+		Rain:          0.10,
+	},
+	RecommendedScore: 65,
+	AcceptableScore:  45,
+}
+
+// ScoreTravel rates the destination against the origin on a 0-100 scale.
+// Relative factors score 50 when both places are equal; factors without data
+// are left out and the remaining weights are renormalized.
+func ScoreTravel(origin, dest *domain.TravelConditions, cfg config.RecommendationCfg) *domain.TravelScore {
+	w := cfg.Weights
+	if w == (config.ScoringWeights{}) {
+		w = defaultRecommendation.Weights
+	}
+	if cfg.RecommendedScore == 0 && cfg.AcceptableScore == 0 {
+		cfg.RecommendedScore = defaultRecommendation.RecommendedScore
+		cfg.AcceptableScore = defaultRecommendation.AcceptableScore
+	}
+
+	factors := make([]domain.FactorScore, 0, 7)
+	add := func(factor string, weight, score float64, origin *float64, dest float64) {
+		if weight <= 0 {
+			return
+		}
+		factors = append(factors, domain.FactorScore{
+			Factor:      factor,
+			Weight:      weight,
+			Score:       round1(clamp(score)),
+			Origin:      origin,
+			Destination: dest,
+		})
+	}
+
+	add(domain.FactorTemperature, w.Temperature,
+		50-(dest.Temp2PM-origin.Temp2PM)*10, &origin.Temp2PM, dest.Temp2PM)
+	add(domain.FactorPM25, w.PM25,
+		50-(dest.PM25-origin.PM25)*2, &origin.PM25, dest.PM25)
+	if origin.HeatIndex2PM != nil && dest.HeatIndex2PM != nil {
+		add(domain.FactorHeatIndex, w.HeatIndex,
+			50-(*dest.HeatIndex2PM-*origin.HeatIndex2PM)*8, origin.HeatIndex2PM, *dest.HeatIndex2PM)
+	}
+	if dest.PrecipitationProbability != nil {
+		add(domain.FactorPrecipitation, w.Precipitation,
+			100-*dest.PrecipitationProbability, origin.PrecipitationProbability, *dest.PrecipitationProbability)
+	}
+	if dest.PrecipitationSum != nil {
+		add(domain.FactorRain, w.Rain,
+			rainScore(*dest.PrecipitationSum), origin.PrecipitationSum, *dest.PrecipitationSum)
+	}
+	if dest.WindSpeed2PM != nil {
+		add(domain.FactorWind, w.Wind,
+			windScore(*dest.WindSpeed2PM), origin.WindSpeed2PM, *dest.WindSpeed2PM)
+	}
+	if dest.UVIndexMax != nil {
+		add(domain.FactorUV, w.UV,
+			uvScore(*dest.UVIndexMax), origin.UVIndexMax, *dest.UVIndexMax)
+	}
+
+	var sum, total float64
+	for _, f := range factors {
+		sum += f.Score * f.Weight
+		total += f.Weight
+	}
+	score := 50.0
+	if total > 0 {
+		score = round1(sum / total)
+	}
+
+	verdict := domain.VerdictNotRecommended
+	switch {
+	case score >= cfg.RecommendedScore:
+		verdict = domain.VerdictRecommended
+	case score >= cfg.AcceptableScore:
+		verdict = domain.VerdictAcceptable
+	}
+
+	return &domain.TravelScore{
+		Score:   score,
+		Verdict: verdict,
+		Factors: factors,
+	}
+}
+
+// windScore treats up to 20 km/h as a pleasant breeze and 60 km/h or more as unsafe
+func windScore(kmh float64) float64 {
+	if kmh <= 20 {
+		return 100
+	}
+	return 100 - (kmh-20)*2.5
+}
+
+// rainScore treats up to 1 mm a day as dry and 26 mm or more as a washout
+func rainScore(mm float64) float64 {
+	if mm <= 1 {
+		return 100
+	}
+	return 100 - (mm-1)*4
+}
+
+// uvScore treats a UV index up to 2 as safe and 11, extreme, or more as the worst
+func uvScore(index float64) float64 {
+	if index <= 2 {
+		return 100
+	}
+	return 100 - (index-2)*100/9
+}
+
+// scoresUV reports whether the optional UV factor is weighted, only then is
+// the daily sun forecast of the destinations fetched
+func scoresUV(cfg config.RecommendationCfg) bool {
+	w := cfg.Weights
+	if w == (config.ScoringWeights{}) {
+		w = defaultRecommendation.Weights
+	}
+	return w.UV > 0
+}
+
+// ApplyRainTolerance reweights the rain factors: a low tolerance doubles them,
+// a high one drops them and medium, the default, keeps the configured weights
+func ApplyRainTolerance(cfg config.RecommendationCfg, tol string) (config.RecommendationCfg, error) {
+	if cfg.Weights == (config.ScoringWeights{}) {
+		cfg.Weights = defaultRecommendation.Weights
+	}
+	switch tol {
+	case "", domain.RainToleranceMedium:
+	case domain.RainToleranceLow:
+		cfg.Weights.Precipitation *= 2
+		cfg.Weights.Rain *= 2
+	case domain.RainToleranceHigh:
+		cfg.Weights.Precipitation = 0
+		cfg.Weights.Rain = 0
+	default:
+		return cfg, fmt.Errorf("%w: %q", domain.ErrInvalidRainTolerance, tol)
+	}
+	return cfg, nil
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(100, v))
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"testing"
+	"travel_advisor/domain"
+	"travel_advisor/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreTravel(t *testing.T) {
+	origin := &domain.TravelConditions{Temp2PM: 32, PM25: 60}
+
+	tests := []struct {
+		name            string
+		dest            *domain.TravelConditions
+		expectedVerdict string
+		expectedScore   float64
+	}{
+		{
+			name:            "Cooler and cleaner is recommended",
+			dest:            &domain.TravelConditions{Temp2PM: 29, PM25: 40},
+			expectedVerdict: domain.VerdictRecommended,
+			expectedScore:   84.6,
+		},
+		{
+			name:            "Cooler but dirtier is acceptable",
+			dest:            &domain.TravelConditions{Temp2PM: 30, PM25: 65},
+			expectedVerdict: domain.VerdictAcceptable,
+			expectedScore:   56.2,
+		},
+		{
+			name:            "Hotter and dirtier is not recommended",
+			dest:            &domain.TravelConditions{Temp2PM: 34, PM25: 75},
+			expectedVerdict: domain.VerdictNotRecommended,
+			expectedScore:   25.4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := ScoreTravel(origin, tt.dest, config.RecommendationCfg{})
+
+			assert.Equal(t, tt.expectedVerdict, score.Verdict)
+			assert.InDelta(t, tt.expectedScore, score.Score, 0.05)
+			assert.Len(t, score.Factors, 2, "factors without data are left out")
+		})
+	}
+}
+
+func TestScoreTravel_OptionalFactors(t *testing.T) {
+	rain, wind := 90.0, 45.0
+	origin := &domain.TravelConditions{Temp2PM: 30, PM25: 50}
+	dest := &domain.TravelConditions{Temp2PM: 30, PM25: 50, PrecipitationProbability: &rain, WindSpeed2PM: &wind}
+
+	score := ScoreTravel(origin, dest, config.RecommendationCfg{
+		Weights: config.ScoringWeights{Temperature: 1, PM25: 1, Precipitation: 1, Wind: 1},
+	})
+
+	assert.Equal(t, []string{domain.FactorTemperature, domain.FactorPM25, domain.FactorPrecipitation, domain.FactorWind},
+		factorNames(score.Factors))
+	// (50 + 50 + 10 + 37.5) / 4
+	assert.InDelta(t, 36.9, score.Score, 0.05)
+	assert.Equal(t, domain.VerdictNotRecommended, score.Verdict)
+}
+
+func factorNames(fs []domain.FactorScore) []string {
+	names := make([]string, 0, len(fs))
+	for _, f := range fs {
+		names = append(names, f.Factor)
+	}
+	return names
+}
+
+func TestScoreTravel_Rain(t *testing.T) {
+	origin := &domain.TravelConditions{Temp2PM: 30, PM25: 50}
+	dest := &domain.TravelConditions{Temp2PM: 30, PM25: 50, PrecipitationSum: floatPtr(16)}
+	weights := config.RecommendationCfg{Weights: config.ScoringWeights{Temperature: 1, PM25: 1, Rain: 1}}
+
+	tests := []struct {
+		name          string
+		tolerance     string
+		expectedScore float64
+		expectedErr   error
+	}{
+		// (50 + 50 + 40) / 3
+		{name: "Medium keeps the weights", tolerance: domain.RainToleranceMedium, expectedScore: 46.7},
+		// (50 + 50 + 40*2) / 4
+		{name: "Low doubles rain", tolerance: domain.RainToleranceLow, expectedScore: 45},
+		{name: "High ignores rain", tolerance: domain.RainToleranceHigh, expectedScore: 50},
+		{name: "Unknown tolerance", tolerance: "none", expectedErr: domain.ErrInvalidRainTolerance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := ApplyRainTolerance(weights, tt.tolerance)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expectedScore, ScoreTravel(origin, dest, cfg).Score, 0.05)
+		})
+	}
+}
+
+func TestScoreTravel_UV(t *testing.T) {
+	origin := &domain.TravelConditions{Temp2PM: 30, PM25: 50}
+	dest := &domain.TravelConditions{Temp2PM: 30, PM25: 50, UVIndexMax: floatPtr(11)}
+
+	unweighted := ScoreTravel(origin, dest, config.RecommendationCfg{Weights: config.ScoringWeights{Temperature: 1, PM25: 1}})
+	assert.Equal(t, 50.0, unweighted.Score, "UV is left out until it is weighted")
+	assert.Len(t, unweighted.Factors, 2)
+
+	// (50 + 50 + 0) / 3
+	weighted := ScoreTravel(origin, dest, config.RecommendationCfg{Weights: config.ScoringWeights{Temperature: 1, PM25: 1, UV: 1}})
+	assert.InDelta(t, 33.3, weighted.Score, 0.05)
+	assert.Equal(t, domain.FactorUV, weighted.Factors[2].Factor)
+}
+
+func TestScoresUV(t *testing.T) {
+	assert.False(t, scoresUV(config.RecommendationCfg{}), "UV is not weighted by default")
+	assert.False(t, scoresUV(config.RecommendationCfg{Weights: config.ScoringWeights{Temperature: 1}}))
+	assert.True(t, scoresUV(config.RecommendationCfg{Weights: config.ScoringWeights{Temperature: 1, UV: 0.1}}))
+}
@@ -3,29 +3,161 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"sync"
+	"time"
 	"travel_advisor/domain"
 	"travel_advisor/helpers"
 	"travel_advisor/pkg/cache"
+	"travel_advisor/pkg/config"
 	"travel_advisor/pkg/conn"
+	"travel_advisor/pkg/geo"
 	"travel_advisor/pkg/log"
+	"travel_advisor/pkg/stats"
 )
 
 type TravelUsecase struct {
-	CacheRepository     cache.Cache
-	DistrictsRepository domain.DistrictRepository
+	CacheRepository          cache.Cache
+	DistrictsRepository      domain.DistrictRepository
+	ClimateNormalsRepository domain.ClimateNormalRepository
+
+	// revalidating holds the districts being refreshed in the background,
+	// keyed by name, with the retry-after time of a failed refresh
+	revalidating sync.Map
 }
 
-func NewTravelUsecase(c cache.Cache, d domain.DistrictRepository) domain.TravelUsecase {
+func NewTravelUsecase(c cache.Cache, d domain.DistrictRepository, n domain.ClimateNormalRepository) domain.TravelUsecase {
 	return &TravelUsecase{
-		CacheRepository:     c,
-		DistrictsRepository: d,
+		CacheRepository:          c,
+		DistrictsRepository:      d,
+		ClimateNormalsRepository: n,
+	}
+}
+
+func (t *TravelUsecase) CoolestDistricts(ctx context.Context, ctr *domain.CoolestCriteria) ([]domain.DistrictCache, error) {
+
+	if err := validateCoolestCriteria(ctr); err != nil {
+		return nil, err
+	}
+
+	districts, err := t.cachedDistricts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctr.Lat != nil {
+		districts, err = t.withinRadius(ctx, districts, *ctr.Lat, *ctr.Long, ctr.RadiusKm)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	districts = RankDistricts(districts, ctr.SortBy, ctr.RainTolerance)
+
+	if len(districts) > 10 {
+		districts = districts[:10]
+	}
+
+	t.attachAnomalies(ctx, districts, today())
+
+	return districts, nil
+}
+
+// RankDistricts orders the districts by sortBy. A low rain tolerance drops
+// rainy districts, medium puts them after the dry ones and high ignores rain.
+func RankDistricts(districts []domain.DistrictCache, sortBy, rainTolerance string) []domain.DistrictCache {
+	if rainTolerance == domain.RainToleranceLow {
+		dry := districts[:0:0]
+		for _, d := range districts {
+			if !d.Rainy() {
+				dry = append(dry, d)
+			}
+		}
+		districts = dry
+	}
+	avoidRain := rainTolerance != domain.RainToleranceHigh
+
+	sort.SliceStable(districts, func(i, j int) bool {
+		if avoidRain && districts[i].Rainy() != districts[j].Rainy() {
+			return !districts[i].Rainy()
+		}
+		if sortBy == domain.SortByDistance && *districts[i].DistanceKm != *districts[j].DistanceKm {
+			return *districts[i].DistanceKm < *districts[j].DistanceKm
+		}
+		if sortBy == domain.SortByFeelsLike && districts[i].FeelsLike() != districts[j].FeelsLike() {
+			return districts[i].FeelsLike() < districts[j].FeelsLike()
+		}
+		if districts[i].Temp == districts[j].Temp {
+			return districts[i].PM25 < districts[j].PM25
+		}
+		return districts[i].Temp < districts[j].Temp
+	})
+	return districts
+}
+
+func validateCoolestCriteria(ctr *domain.CoolestCriteria) error {
+	located := ctr.Lat != nil || ctr.Long != nil || ctr.RadiusKm != 0
+	if located && (ctr.Lat == nil || ctr.Long == nil || ctr.RadiusKm <= 0) {
+		return domain.ErrInvalidLocation
+	}
+	switch ctr.RainTolerance {
+	case "", domain.RainToleranceLow, domain.RainToleranceMedium, domain.RainToleranceHigh:
+	default:
+		return fmt.Errorf("%w: %q", domain.ErrInvalidRainTolerance, ctr.RainTolerance)
+	}
+	switch ctr.SortBy {
+	case "", domain.SortByCoolness, domain.SortByFeelsLike:
+		return nil
+	case domain.SortByDistance:
+		if !located {
+			return fmt.Errorf("%w: sorting by distance needs a location", domain.ErrInvalidSort)
+		}
+		return nil
+	default:
+		return domain.ErrInvalidSort
+	}
+}
+
+// withinRadius keeps the cached districts within radiusKm of lat/long and
+// fills their distance
+func (t *TravelUsecase) withinRadius(
+	ctx context.Context,
+	cached []domain.DistrictCache,
+	lat, long, radiusKm float64,
+) ([]domain.DistrictCache, error) {
+
+	all, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{})
+	if err != nil {
+		return nil, err
 	}
+	byName := make(map[string]*domain.District, len(all))
+	for _, d := range all {
+		byName[d.Name] = d
+	}
+
+	nearby := make([]domain.DistrictCache, 0, len(cached))
+	for _, c := range cached {
+		d, ok := byName[c.Name]
+		if !ok {
+			continue
+		}
+		dist := round1(geo.Distance(lat, long, d.Lat, d.Long))
+		if dist > radiusKm {
+			continue
+		}
+		c.DistanceKm = &dist
+		nearby = append(nearby, c)
+	}
+	return nearby, nil
 }
 
-func (t *TravelUsecase) CoolestDistricts(ctx context.Context) ([]domain.DistrictCache, error) {
+// cachedDistricts returns every district the scheduler has cached, skipping
+// entries that vanished, can not be decoded or are of another cache version.
+// Stale entries are returned as they are and refreshed in the background.
+func (t *TravelUsecase) cachedDistricts(ctx context.Context) ([]domain.DistrictCache, error) {
 
 	districtNames, err := t.CacheRepository.Keys(ctx)
 	log.Println(districtNames)
@@ -46,22 +178,98 @@ func (t *TravelUsecase) CoolestDistricts(ctx context.Context) ([]domain.District
 		if err := json.Unmarshal([]byte(dataStr), &d); err != nil {
 			continue
 		}
+		if d.Version != domain.DistrictCacheVersion {
+			continue
+		}
 
 		districts = append(districts, d)
 	}
+	t.revalidate(StaleDistricts(districts, time.Now(), staleAfter()))
+	return districts, nil
+}
 
-	sort.Slice(districts, func(i, j int) bool {
-		if districts[i].AvgTemp2PM == districts[j].AvgTemp2PM {
-			return districts[i].AvgPM25 < districts[j].AvgPM25
-		}
-		return districts[i].AvgTemp2PM < districts[j].AvgTemp2PM
-	})
+// attachAnomalies compares the cached means to the mean of the climate
+// normals over the horizon days from start. Other statistics are not
+// comparable to a normal and get no anomaly. Normals are supplementary, so
+// failures only skip the anomaly.
+func (t *TravelUsecase) attachAnomalies(ctx context.Context, districts []domain.DistrictCache, start time.Time) {
+	if t.ClimateNormalsRepository == nil || !slices.ContainsFunc(districts, isMeanEntry) {
+		return
+	}
+	agg, err := helpers.NewAggregation(config.Aggregation())
+	if err != nil {
+		agg = helpers.DefaultAggregation
+	}
+	days := make([]int, agg.HorizonDays)
+	for i := range days {
+		days[i] = start.AddDate(0, 0, i).YearDay()
+	}
+	byDay, err := t.ClimateNormalsRepository.ListByDays(ctx, days)
+	if err != nil {
+		log.Warn("climate normals fetch failed ", err)
+		return
+	}
+	if len(byDay) == 0 {
+		return
+	}
+	all, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{})
+	if err != nil {
+		log.Warn("districts fetch failed ", err)
+		return
+	}
+	ids := make(map[string]int64, len(all))
+	for _, d := range all {
+		ids[d.Name] = d.ID
+	}
 
-	if len(districts) > 10 {
-		districts = districts[:10]
+	for i := range districts {
+		if !isMeanEntry(districts[i]) {
+			continue
+		}
+		var normals []*domain.DistrictClimateNormal
+		for _, day := range days {
+			if n, ok := byDay[day][ids[districts[i].Name]]; ok {
+				normals = append(normals, n)
+			}
+		}
+		districts[i].Anomaly = domain.MeanNormal(normals).Anomaly(districts[i].Temp, districts[i].PM25)
 	}
+}
 
-	return districts, nil
+func isMeanEntry(d domain.DistrictCache) bool {
+	return d.Statistic == string(stats.Mean)
+}
+
+// climateNormals loads the normals of every date in one query, keyed by
+// date and district id. Normals are supplementary, so failures only skip
+// the anomalies.
+func (t *TravelUsecase) climateNormals(ctx context.Context, dates ...string) map[string]map[int64]*domain.DistrictClimateNormal {
+	if t.ClimateNormalsRepository == nil {
+		return nil
+	}
+	days := make(map[string]int, len(dates))
+	daysOfYear := make([]int, 0, len(dates))
+	for _, date := range dates {
+		day, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			continue
+		}
+		days[date] = day.YearDay()
+		daysOfYear = append(daysOfYear, day.YearDay())
+	}
+	if len(daysOfYear) == 0 {
+		return nil
+	}
+	byDay, err := t.ClimateNormalsRepository.ListByDays(ctx, daysOfYear)
+	if err != nil {
+		log.Warn("climate normals fetch failed ", err)
+		return nil
+	}
+	normals := make(map[string]map[int64]*domain.DistrictClimateNormal, len(days))
+	for date, day := range days {
+		normals[date] = byDay[day]
+	}
+	return normals
 }
 
 func (t *TravelUsecase) RecommendTravel(
@@ -69,113 +277,107 @@ func (t *TravelUsecase) RecommendTravel(
 	req domain.TravelRecommendationRequest,
 ) (*domain.TravelRecommendationResponse, error) {
 
+	cfg, err := ApplyRainTolerance(config.Recommendation(), req.RainTolerance)
+	if err != nil {
+		return nil, err
+	}
+
 	districts, err := t.DistrictsRepository.List(ctx, &domain.DistrictCriteria{
 		DistrictName: &req.DestinationDistrict,
 	})
 	if err != nil || len(districts) == 0 {
-		return nil, fmt.Errorf("destination district not found")
+		return nil, domain.ErrDestinationNotFound
 	}
 	destDistrict := districts[0]
 
-	conn.InitClient()
 	client := conn.GetHTTClient()
 
 	date := req.TravelDate
 
 	var (
-		destTemp    float64
-		destPM25    float64
-		currentTemp float64
-		currentPM25 float64
-
-		errDestTemp error
-		errDestPM25 error
-		errCurTemp  error
-		errCurPM25  error
+		dest    *domain.TravelConditions
+		current *domain.TravelConditions
+
+		errDest    error
+		errCurrent error
 	)
 
 	wg := sync.WaitGroup{}
-	wg.Add(4)
+	wg.Add(2)
 
 	go func() {
 		defer wg.Done()
-		destTemp, errDestTemp = helpers.FetchAvgTempAt2PM(
+		dest, errDest = helpers.FetchTravelConditions(
 			ctx,
 			client,
 			destDistrict.Lat,
 			destDistrict.Long,
-			&date,
+			date,
+			scoresUV(cfg),
 		)
 	}()
 
 	go func() {
 		defer wg.Done()
-		destPM25, errDestPM25 = helpers.FetchAvgPM25(
-			ctx,
-			client,
-			destDistrict.Lat,
-			destDistrict.Long,
-			&date,
-		)
-	}()
-
-	go func() {
-		defer wg.Done()
-		currentTemp, errCurTemp = helpers.FetchAvgTempAt2PM(
+		current, errCurrent = helpers.FetchTravelConditions(
 			ctx,
 			client,
 			req.CurrentLat,
 			req.CurrentLong,
-			&date,
-		)
-	}()
-
-	go func() {
-		defer wg.Done()
-		currentPM25, errCurPM25 = helpers.FetchAvgPM25(
-			ctx,
-			client,
-			req.CurrentLat,
-			req.CurrentLong,
-			&date,
+			date,
+			false,
 		)
 	}()
 
 	wg.Wait()
 
-	if errDestTemp != nil {
-		return nil, errDestTemp
-	}
-	if errDestPM25 != nil {
-		return nil, errDestPM25
+	if err := errors.Join(errDest, errCurrent); err != nil {
+		resp, fallbackErr := t.staleRecommendation(ctx, req, destDistrict, cfg)
+		if fallbackErr != nil {
+			log.Warn("cached fallback unavailable ", fallbackErr)
+			return nil, err
+		}
+		log.Warn("live forecasts unavailable, serving cached data ", err)
+		return resp, nil
 	}
-	if errCurTemp != nil {
-		return nil, errCurTemp
+
+	normals := t.climateNormals(ctx, date)
+	resp := t.buildRecommendation(destDistrict, current, dest, normals[date][destDistrict.ID], cfg)
+	resp.Confidence = t.forecastConfidence(ctx, client, req.CurrentLat, req.CurrentLong, destDistrict, date)
+	if resp.Confidence != nil && resp.Confidence.Level == domain.ConfidenceLow {
+		resp.Reason += " The forecast is uncertain, check again closer to the date."
 	}
-	if errCurPM25 != nil {
-		return nil, errCurPM25
+	if resp.Recommendation == domain.VerdictNotRecommended {
+		resp.Alternatives = t.alternatives(ctx, destDistrict, req.CurrentLat, req.CurrentLong, current)
 	}
+	return resp, nil
+}
 
-	tempDiff := destTemp - currentTemp
-	pm25Diff := destPM25 - currentPM25
+// buildRecommendation scores and explains a destination against the origin
+// conditions, normal is the destination's climate normal for the date and
+// may be nil
+func (t *TravelUsecase) buildRecommendation(
+	destDistrict *domain.District,
+	current, dest *domain.TravelConditions,
+	normal *domain.DistrictClimateNormal,
+	cfg config.RecommendationCfg,
+) *domain.TravelRecommendationResponse {
 
-	resp := &domain.TravelRecommendationResponse{
+	score := ScoreTravel(current, dest, cfg)
+	reasons := BuildExplanation(current, dest)
+
+	return &domain.TravelRecommendationResponse{
 		Destination:    destDistrict.Name,
-		TempDiff:       tempDiff,
-		PM25Diff:       pm25Diff,
-		Recommendation: "Not Recommended",
-	}
+		TempDiff:       dest.Temp2PM - current.Temp2PM,
+		PM25Diff:       dest.PM25 - current.PM25,
+		Recommendation: score.Verdict,
+		Score:          score.Score,
+		Factors:        score.Factors,
+		Reasons:        reasons,
+		Reason:         RenderExplanation(score.Verdict, reasons),
 
-	if tempDiff < 0 && pm25Diff < 0 {
-		resp.Recommendation = "Recommended"
-		resp.Reason = fmt.Sprintf(
-			"Your destination is %.1f°C cooler and has significantly better air quality. Enjoy your trip!",
-			-tempDiff,
-		)
-	} else {
-		resp.Reason = "Your destination is hotter and has worse air quality than your current location." +
-			" It’s better to stay where you are."
+		DestinationAnomaly: normal.Anomaly(dest.Temp2PM, dest.PM25),
+		OriginAQI:          current.AQI,
+		DestinationAQI:     dest.AQI,
 	}
-
-	return resp, nil
 }
@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 	"travel_advisor/domain"
+	"travel_advisor/helpers"
+	"travel_advisor/pkg/config"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -47,6 +49,20 @@ func (m *MockDistrictRepository) List(ctx context.Context, ctr *domain.DistrictC
 	return args.Get(0).([]*domain.District), args.Error(1)
 }
 
+type MockClimateNormalRepository struct {
+	mock.Mock
+}
+
+func (m *MockClimateNormalRepository) Recompute(ctx context.Context, windowDays int, timeZone string) (int64, error) {
+	args := m.Called(ctx, windowDays, timeZone)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockClimateNormalRepository) ListByDays(ctx context.Context, daysOfYear []int) (map[int]map[int64]*domain.DistrictClimateNormal, error) {
+	args := m.Called(ctx, daysOfYear)
+	return args.Get(0).(map[int]map[int64]*domain.DistrictClimateNormal), args.Error(1)
+}
+
 func TestTravelUsecase_CoolestDistricts(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -60,9 +76,9 @@ func TestTravelUsecase_CoolestDistricts(t *testing.T) {
 
 				mockCache.On("Keys", mock.Anything).Return([]string{"Dhaka", "Chittagong", "Sylhet"}, nil)
 
-				dhakaData := domain.DistrictCache{Name: "Dhaka", AvgTemp2PM: 30.5, AvgPM25: 45.2}
-				chittagongData := domain.DistrictCache{Name: "Chittagong", AvgTemp2PM: 28.3, AvgPM25: 35.1}
-				sylhetData := domain.DistrictCache{Name: "Sylhet", AvgTemp2PM: 26.8, AvgPM25: 25.5}
+				dhakaData := domain.DistrictCache{Version: domain.DistrictCacheVersion, Name: "Dhaka", Temp: 30.5, PM25: 45.2}
+				chittagongData := domain.DistrictCache{Version: domain.DistrictCacheVersion, Name: "Chittagong", Temp: 28.3, PM25: 35.1}
+				sylhetData := domain.DistrictCache{Version: domain.DistrictCacheVersion, Name: "Sylhet", Temp: 26.8, PM25: 25.5}
 
 				dhakaJSON, _ := json.Marshal(dhakaData)
 				chittagongJSON, _ := json.Marshal(chittagongData)
@@ -73,9 +89,9 @@ func TestTravelUsecase_CoolestDistricts(t *testing.T) {
 				mockCache.On("Get", mock.Anything, "Sylhet").Return(string(sylhetJSON), nil)
 			},
 			expectedResult: []domain.DistrictCache{
-				{Name: "Sylhet", AvgTemp2PM: 26.8, AvgPM25: 25.5},
-				{Name: "Chittagong", AvgTemp2PM: 28.3, AvgPM25: 35.1},
-				{Name: "Dhaka", AvgTemp2PM: 30.5, AvgPM25: 45.2},
+				{Version: domain.DistrictCacheVersion, Name: "Sylhet", Temp: 26.8, PM25: 25.5},
+				{Version: domain.DistrictCacheVersion, Name: "Chittagong", Temp: 28.3, PM25: 35.1},
+				{Version: domain.DistrictCacheVersion, Name: "Dhaka", Temp: 30.5, PM25: 45.2},
 			},
 			expectedError: nil,
 		},
@@ -107,9 +123,10 @@ func TestTravelUsecase_CoolestDistricts(t *testing.T) {
 
 				for i := 0; i < 12; i++ {
 					districtData := domain.DistrictCache{
-						Name:       fmt.Sprintf("District%d", i),
-						AvgTemp2PM: float64(20 + i),
-						AvgPM25:    float64(10 + i),
+						Version: domain.DistrictCacheVersion,
+						Name:    fmt.Sprintf("District%d", i),
+						Temp:    float64(20 + i),
+						PM25:    float64(10 + i),
 					}
 					districtJSON, _ := json.Marshal(districtData)
 					mockCache.On("Get", mock.Anything, fmt.Sprintf("District%d", i)).Return(string(districtJSON), nil)
@@ -119,9 +136,10 @@ func TestTravelUsecase_CoolestDistricts(t *testing.T) {
 				result := make([]domain.DistrictCache, 10)
 				for i := 0; i < 10; i++ {
 					result[i] = domain.DistrictCache{
-						Name:       fmt.Sprintf("District%d", i),
-						AvgTemp2PM: float64(20 + i),
-						AvgPM25:    float64(10 + i),
+						Version: domain.DistrictCacheVersion,
+						Name:    fmt.Sprintf("District%d", i),
+						Temp:    float64(20 + i),
+						PM25:    float64(10 + i),
 					}
 				}
 				return result
@@ -137,9 +155,9 @@ func TestTravelUsecase_CoolestDistricts(t *testing.T) {
 
 			tt.setupMocks(mockCache, mockDistrictRepo)
 
-			usecase := NewTravelUsecase(mockCache, mockDistrictRepo)
+			usecase := NewTravelUsecase(mockCache, mockDistrictRepo, nil)
 
-			result, err := usecase.CoolestDistricts(context.Background())
+			result, err := usecase.CoolestDistricts(context.Background(), &domain.CoolestCriteria{})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -155,6 +173,74 @@ func TestTravelUsecase_CoolestDistricts(t *testing.T) {
 	}
 }
 
+func TestTravelUsecase_CoolestDistricts_Anomalies(t *testing.T) {
+	mockCache := new(MockCache)
+	mockDistrictRepo := new(MockDistrictRepository)
+	mockNormals := new(MockClimateNormalRepository)
+
+	entries := []domain.DistrictCache{
+		{Version: domain.DistrictCacheVersion, Name: "Sylhet", Statistic: "mean", Temp: 26.8, PM25: 25.5},
+		{Version: domain.DistrictCacheVersion, Name: "Khulna", Statistic: "p90", Temp: 29.0, PM25: 35.0},
+		{Version: domain.DistrictCacheVersion, Name: "Dhaka", Statistic: "mean", Temp: 30.5, PM25: 45.2},
+	}
+	var names []string
+	for _, e := range entries {
+		bytes, _ := json.Marshal(e)
+		names = append(names, e.Name)
+		mockCache.On("Get", mock.Anything, e.Name).Return(string(bytes), nil)
+	}
+	mockCache.On("Keys", mock.Anything).Return(names, nil)
+
+	mockDistrictRepo.On("List", mock.Anything, &domain.DistrictCriteria{}).Return([]*domain.District{
+		{ID: 36, Name: "Sylhet"},
+		{ID: 40, Name: "Khulna"},
+		{ID: 47, Name: "Dhaka"},
+	}, nil)
+	first, second := today().YearDay(), today().AddDate(0, 0, 1).YearDay()
+	horizon := mock.MatchedBy(func(days []int) bool {
+		return len(days) == helpers.DefaultAggregation.HorizonDays && days[0] == first
+	})
+	mockNormals.On("ListByDays", mock.Anything, horizon).Return(map[int]map[int64]*domain.DistrictClimateNormal{
+		first: {
+			36: {DistrictID: 36, Temp2PMMean: floatPtr(24.0), Temp2PMStdDev: floatPtr(1.0), PM25Mean: floatPtr(30.5)},
+			40: {DistrictID: 40, Temp2PMMean: floatPtr(27.0), Temp2PMStdDev: floatPtr(1.0)},
+		},
+		second: {
+			36: {DistrictID: 36, Temp2PMMean: floatPtr(25.6), Temp2PMStdDev: floatPtr(1.0)},
+		},
+	}, nil)
+
+	usecase := NewTravelUsecase(mockCache, mockDistrictRepo, mockNormals)
+	result, err := usecase.CoolestDistricts(context.Background(), &domain.CoolestCriteria{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, "Sylhet", result[0].Name)
+	assert.InDelta(t, 2.0, *result[0].Anomaly.TempAnomaly, 1e-9, "against the mean normal of the horizon")
+	assert.InDelta(t, 2.0, *result[0].Anomaly.TempZScore, 1e-9)
+	assert.InDelta(t, -5.0, *result[0].Anomaly.PM25Anomaly, 1e-9)
+	assert.Nil(t, result[0].Anomaly.PM25ZScore, "no z-score without a standard deviation")
+	assert.Equal(t, "Khulna", result[1].Name)
+	assert.Nil(t, result[1].Anomaly, "a p90 is not comparable to a normal")
+	assert.Nil(t, result[2].Anomaly, "Dhaka has no normal")
+}
+
+func TestTravelUsecase_ClimateNormals(t *testing.T) {
+	mockNormals := new(MockClimateNormalRepository)
+	// 2025-03-01 and 2025-03-02 are days 60 and 61, the bad date is skipped
+	mockNormals.On("ListByDays", mock.Anything, []int{60, 61}).Return(map[int]map[int64]*domain.DistrictClimateNormal{
+		60: {47: {DistrictID: 47, Temp2PMMean: floatPtr(28.0)}},
+	}, nil).Once()
+
+	usecase := &TravelUsecase{ClimateNormalsRepository: mockNormals}
+	normals := usecase.climateNormals(context.Background(), "2025-03-01", "2025-03-02", "tomorrow")
+
+	assert.Equal(t, 28.0, *normals["2025-03-01"][47].Temp2PMMean)
+	assert.Nil(t, normals["2025-03-02"][47])
+	assert.NotContains(t, normals, "tomorrow")
+	mockNormals.AssertExpectations(t)
+}
+
 func TestTravelUsecase_RecommendTravel(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -185,7 +271,7 @@ func TestTravelUsecase_RecommendTravel(t *testing.T) {
 			expectedResult: &domain.TravelRecommendationResponse{
 				Destination:    "Sylhet",
 				Recommendation: "Recommended",
-				Reason:         "Your destination is 2.0°C cooler and has significantly better air quality. Enjoy your trip!",
+				Reason:         "Your destination is 2°C cooler and PM2.5 is 5 µg/m³ lower. Enjoy your trip!",
 				TempDiff:       -2.0,
 				PM25Diff:       -5.0,
 			},
@@ -229,7 +315,7 @@ func TestTravelUsecase_RecommendTravel(t *testing.T) {
 			expectedResult: &domain.TravelRecommendationResponse{
 				Destination:    "Dhaka",
 				Recommendation: "Not Recommended",
-				Reason:         "Your destination is hotter and has worse air quality than your current location. It's better to stay where you are.",
+				Reason:         "Your destination is 3.5°C hotter and PM2.5 is 10.2 µg/m³ higher. It’s better to stay where you are.",
 				TempDiff:       3.5,
 				PM25Diff:       10.2,
 			},
@@ -244,7 +330,7 @@ func TestTravelUsecase_RecommendTravel(t *testing.T) {
 
 			tt.setupMocks(mockCache, mockDistrictRepo)
 
-			usecase := NewTravelUsecase(mockCache, mockDistrictRepo)
+			usecase := NewTravelUsecase(mockCache, mockDistrictRepo, nil)
 
 			result, err := usecase.RecommendTravel(context.Background(), tt.request)
 
@@ -266,3 +352,527 @@ func TestTravelUsecase_RecommendTravel(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestTravelUsecase_CompareDestinations_Validation(t *testing.T) {
+	tests := []struct {
+		name          string
+		destinations  []string
+		expectedError error
+	}{
+		{
+			name:          "Error - No destinations",
+			destinations:  []string{"", ""},
+			expectedError: domain.ErrNoDestinations,
+		},
+		{
+			name:          "Error - Too many destinations",
+			destinations:  []string{"Sylhet", "Bandarban", "Rangamati", "Coxsbazar", "Khulna", "Dhaka"},
+			expectedError: domain.ErrTooManyDestinations,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewTravelUsecase(new(MockCache), new(MockDistrictRepository), nil)
+
+			_, err := usecase.CompareDestinations(context.Background(), domain.TravelCompareRequest{
+				DestinationDistricts: tt.destinations,
+				TravelDate:           "2024-01-15",
+			})
+
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestRankComparedDestinations(t *testing.T) {
+	compared := []domain.ComparedDestination{
+		{TravelRecommendationResponse: &domain.TravelRecommendationResponse{Destination: "Dhaka", Score: 40}},
+		{TravelRecommendationResponse: &domain.TravelRecommendationResponse{Destination: "Sylhet", Score: 70, TempDiff: -1}},
+		{TravelRecommendationResponse: &domain.TravelRecommendationResponse{Destination: "Bandarban", Score: 70, TempDiff: -3}},
+	}
+
+	RankComparedDestinations(compared)
+
+	assert.Equal(t, "Bandarban", compared[0].Destination)
+	assert.Equal(t, "Sylhet", compared[1].Destination)
+	assert.Equal(t, "Dhaka", compared[2].Destination)
+	assert.Equal(t, 3, compared[2].Rank)
+}
+
+func TestValidateDateWindow(t *testing.T) {
+	today := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "Success - Whole horizon", from: "2024-01-15", to: "2024-01-21"},
+		{name: "Success - Single day", from: "2024-01-17", to: "2024-01-17"},
+		{name: "Error - Invalid format", from: "15-01-2024", to: "2024-01-16", wantErr: true},
+		{name: "Error - Reversed", from: "2024-01-18", to: "2024-01-16", wantErr: true},
+		{name: "Error - In the past", from: "2024-01-14", to: "2024-01-16", wantErr: true},
+		{name: "Error - Beyond horizon", from: "2024-01-16", to: "2024-01-22", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDateWindow(tt.from, tt.to, today)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidDateRange)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRankTravelDates(t *testing.T) {
+	dates := []domain.RankedTravelDate{
+		{Date: "2024-01-16", TravelRecommendationResponse: &domain.TravelRecommendationResponse{Score: 60}},
+		{Date: "2024-01-18", TravelRecommendationResponse: &domain.TravelRecommendationResponse{Score: 75}},
+		{Date: "2024-01-17", TravelRecommendationResponse: &domain.TravelRecommendationResponse{Score: 75}},
+	}
+
+	RankTravelDates(dates)
+
+	assert.Equal(t, "2024-01-17", dates[0].Date)
+	assert.Equal(t, "2024-01-18", dates[1].Date)
+	assert.Equal(t, "2024-01-16", dates[2].Date)
+	assert.Equal(t, 3, dates[2].Rank)
+}
+
+func TestBestWindow(t *testing.T) {
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.FixedZone("", 6*3600))
+	scores := map[int]float64{5: 1, 6: 10, 7: 12, 8: 11, 9: 40, 10: 50, 11: 30, 12: 20, 13: 25, 14: 5}
+
+	var hours []*domain.HourlyCondition
+	for h := 5; h <= 14; h++ {
+		if h == 12 {
+			continue // gap in the series
+		}
+		s := scores[h]
+		hours = append(hours, &domain.HourlyCondition{Time: day.Add(time.Duration(h) * time.Hour), Score: &s})
+	}
+
+	departure := BestWindow(hours, 3, 6, 22, time.Time{})
+	if assert.NotNil(t, departure) {
+		assert.Equal(t, 6, departure.Start.Hour(), "05:00 is before the travel hours")
+		assert.Equal(t, 9, departure.End.Hour())
+		assert.Equal(t, 11.0, departure.Score)
+	}
+
+	arrival := BestWindow(hours, 3, 6, 22, departure.End)
+	if assert.NotNil(t, arrival) {
+		assert.Equal(t, 9, arrival.Start.Hour(), "windows may not span the missing 12:00")
+		assert.Equal(t, 40.0, arrival.Score)
+	}
+
+	assert.Nil(t, BestWindow(hours, 3, 13, 22, time.Time{}))
+}
+
+func TestHourDiscomfort(t *testing.T) {
+	weights := config.ScoringWeights{Temperature: 0.35, PM25: 0.30, HeatIndex: 0.15}
+
+	assert.Nil(t, HourDiscomfort(&domain.HourlyCondition{Temperature: floatPtr(30)}, weights))
+
+	comfortable := HourDiscomfort(&domain.HourlyCondition{Temperature: floatPtr(22), PM25: floatPtr(0)}, weights)
+	assert.Equal(t, 0.0, *comfortable)
+
+	// feels like 40°C is the worst heat, 75 µg/m³ half the worst pollution: (100*0.5 + 50*0.3) / 0.8
+	hot := HourDiscomfort(&domain.HourlyCondition{Temperature: floatPtr(35), HeatIndex: floatPtr(40), PM25: floatPtr(75)}, weights)
+	assert.Equal(t, 81.3, *hot)
+}
+
+func TestTravelUsecase_HourlyForecast_InvalidDate(t *testing.T) {
+	usecase := NewTravelUsecase(new(MockCache), new(MockDistrictRepository), nil)
+
+	_, err := usecase.HourlyForecast(context.Background(), 1, "15-01-2024")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidTravelDate)
+}
+
+func TestTravelUsecase_CoolestDistricts_NearLocation(t *testing.T) {
+	mockCache := new(MockCache)
+	mockDistrictRepo := new(MockDistrictRepository)
+
+	mockCache.On("Keys", mock.Anything).Return([]string{"Dhaka", "Gazipur", "Manikganj", "Sylhet"}, nil)
+	for name, temp := range map[string]float64{"Dhaka": 29, "Gazipur": 28, "Manikganj": 27, "Sylhet": 24} {
+		data, _ := json.Marshal(domain.DistrictCache{Version: domain.DistrictCacheVersion, Name: name, Temp: temp, PM25: 40})
+		mockCache.On("Get", mock.Anything, name).Return(string(data), nil)
+	}
+	mockDistrictRepo.On("List", mock.Anything, &domain.DistrictCriteria{}).Return([]*domain.District{
+		{Name: "Dhaka", Lat: 23.7115, Long: 90.4111},
+		{Name: "Gazipur", Lat: 24.0023, Long: 90.4264},
+		{Name: "Manikganj", Lat: 23.8617, Long: 90.0003},
+		{Name: "Sylhet", Lat: 24.8998, Long: 91.8710},
+	}, nil)
+
+	usecase := NewTravelUsecase(mockCache, mockDistrictRepo, nil)
+	lat, long := 23.7115, 90.4111
+
+	result, err := usecase.CoolestDistricts(context.Background(), &domain.CoolestCriteria{Lat: &lat, Long: &long, RadiusKm: 60})
+	assert.NoError(t, err)
+	if assert.Len(t, result, 3, "Sylhet is out of range") {
+		assert.Equal(t, "Manikganj", result[0].Name)
+		assert.Equal(t, 0.0, *result[2].DistanceKm)
+	}
+
+	result, err = usecase.CoolestDistricts(context.Background(), &domain.CoolestCriteria{
+		Lat: &lat, Long: &long, RadiusKm: 60, SortBy: domain.SortByDistance,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, result, 3) {
+		assert.Equal(t, "Dhaka", result[0].Name)
+		assert.Equal(t, "Manikganj", result[2].Name)
+	}
+}
+
+func TestTravelUsecase_CoolestDistricts_InvalidCriteria(t *testing.T) {
+	lat := 23.7
+	tests := []struct {
+		name          string
+		ctr           *domain.CoolestCriteria
+		expectedError error
+	}{
+		{name: "Error - Missing long", ctr: &domain.CoolestCriteria{Lat: &lat, RadiusKm: 50}, expectedError: domain.ErrInvalidLocation},
+		{name: "Error - Missing radius", ctr: &domain.CoolestCriteria{Lat: &lat, Long: &lat}, expectedError: domain.ErrInvalidLocation},
+		{name: "Error - Distance without location", ctr: &domain.CoolestCriteria{SortBy: domain.SortByDistance}, expectedError: domain.ErrInvalidSort},
+		{name: "Error - Unknown sort", ctr: &domain.CoolestCriteria{SortBy: "name"}, expectedError: domain.ErrInvalidSort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewTravelUsecase(new(MockCache), new(MockDistrictRepository), nil)
+
+			_, err := usecase.CoolestDistricts(context.Background(), tt.ctr)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestSuggestAlternatives(t *testing.T) {
+	districts := []*domain.District{
+		{Name: "Dhaka", Lat: 23.7115, Long: 90.4111},
+		{Name: "Gazipur", Lat: 24.0023, Long: 90.4264},
+		{Name: "Narayanganj", Lat: 23.6238, Long: 90.5000},
+		{Name: "Munshiganj", Lat: 23.5435, Long: 90.5354},
+		{Name: "Manikganj", Lat: 23.8617, Long: 90.0003},
+		{Name: "Sylhet", Lat: 24.8998, Long: 91.8710},
+	}
+	cached := []domain.DistrictCache{
+		{Name: "Dhaka", Temp: 26, PM25: 60},
+		{Name: "Gazipur", Temp: 29, PM25: 60},     // warmer than the origin
+		{Name: "Narayanganj", Temp: 27, PM25: 95}, // dirtier than the origin
+		{Name: "Munshiganj", Temp: 27.5, PM25: 70},
+		{Name: "Manikganj", Temp: 27.5, PM25: 50},
+		{Name: "Sylhet", Temp: 22, PM25: 30}, // too far
+		{Name: "Unknown", Temp: 20, PM25: 10},
+	}
+	origin := &domain.TravelConditions{Temp2PM: 28, PM25: 90}
+
+	got := SuggestAlternatives(cached, districts, districts[0], 23.70, 90.40, origin, 50, 3)
+
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "Manikganj", got[0].District)
+		assert.Equal(t, "Munshiganj", got[1].District)
+		assert.Greater(t, got[0].DistanceFromDestinationKm, 0.0)
+	}
+}
+
+func TestTravelUsecase_Meetup_Validation(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           domain.MeetupRequest
+		expectedError error
+	}{
+		{name: "Error - No origins", req: domain.MeetupRequest{TravelDate: "2024-01-15"}, expectedError: domain.ErrNoOrigins},
+		{
+			name:          "Error - Too many origins",
+			req:           domain.MeetupRequest{Origins: make([]domain.Coordinate, 11), TravelDate: "2024-01-15"},
+			expectedError: domain.ErrTooManyOrigins,
+		},
+		{
+			name:          "Error - Unknown objective",
+			req:           domain.MeetupRequest{Origins: make([]domain.Coordinate, 2), Objective: "fastest"},
+			expectedError: domain.ErrInvalidObjective,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewTravelUsecase(new(MockCache), new(MockDistrictRepository), nil)
+
+			_, err := usecase.Meetup(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestShortlistMeetupDistricts(t *testing.T) {
+	districts := []*domain.District{
+		{Name: "Sylhet", Lat: 24.8998, Long: 91.8710},
+		{Name: "Cumilla", Lat: 23.4682, Long: 91.1788},
+		{Name: "Rajshahi", Lat: 24.3745, Long: 88.6042},
+		{Name: "Feni", Lat: 23.0159, Long: 91.3976},
+	}
+	origins := []domain.Coordinate{{Lat: 23.7104, Long: 90.3944}, {Lat: 22.3569, Long: 91.7832}} // Dhaka, Chattogram
+
+	got := ShortlistMeetupDistricts(districts, origins, domain.MeetupObjectiveMax, 2)
+
+	if assert.Len(t, got, 2) {
+		assert.ElementsMatch(t, []string{"Cumilla", "Feni"}, []string{got[0].Name, got[1].Name})
+	}
+	assert.Len(t, districts, 4, "input is left untouched")
+	assert.Equal(t, "Sylhet", districts[0].Name)
+}
+
+func TestScoreMeetup(t *testing.T) {
+	d := &domain.District{Name: "Cumilla", Lat: 23.4682, Long: 91.1788}
+	origins := []domain.Coordinate{{Lat: 23.7104, Long: 90.3944}, {Lat: 22.3569, Long: 91.7832}}
+	originConds := []*domain.TravelConditions{{Temp2PM: 30, PM25: 90}, {Temp2PM: 29, PM25: 60}}
+	dest := &domain.TravelConditions{Temp2PM: 28, PM25: 60}
+	cfg := config.MeetupCfg{DistanceWeight: 0.4, MaxDistanceKm: 300}
+
+	s := ScoreMeetup(d, dest, origins, originConds, domain.MeetupObjectiveTotal, cfg)
+
+	assert.Len(t, s.Origins, 2)
+	assert.Equal(t, -2.0, s.Origins[0].TempDiff)
+	assert.Greater(t, s.Origins[0].Score, s.Origins[1].Score, "the hotter, dirtier origin gains more")
+	assert.InDelta(t, s.TotalDistanceKm, s.Origins[0].DistanceKm+s.Origins[1].DistanceKm, 0.1)
+	assert.InDelta(t, 0.6*s.ComfortScore+0.4*s.DistanceScore, s.Score, 0.1)
+}
+
+func TestRankMeetupSuggestions(t *testing.T) {
+	suggestions := []domain.MeetupSuggestion{
+		{District: "Feni", Score: 60, TotalDistanceKm: 250},
+		{District: "Cumilla", Score: 70, TotalDistanceKm: 220},
+		{District: "Chandpur", Score: 70, TotalDistanceKm: 200},
+	}
+
+	RankMeetupSuggestions(suggestions)
+
+	assert.Equal(t, "Chandpur", suggestions[0].District)
+	assert.Equal(t, "Cumilla", suggestions[1].District)
+	assert.Equal(t, 3, suggestions[2].Rank)
+}
+
+func TestTravelUsecase_Itinerary_Validation(t *testing.T) {
+	from := today().Format(time.DateOnly)
+	to := today().AddDate(0, 0, 1).Format(time.DateOnly)
+
+	tests := []struct {
+		name          string
+		req           domain.ItineraryRequest
+		expectedError error
+	}{
+		{
+			name:          "Error - Outside the forecast horizon",
+			req:           domain.ItineraryRequest{DateFrom: "2000-01-01", DateTo: "2000-01-02", MustVisit: []string{"Sylhet"}},
+			expectedError: domain.ErrInvalidDateRange,
+		},
+		{
+			name:          "Error - Nothing to visit",
+			req:           domain.ItineraryRequest{DateFrom: from, DateTo: to},
+			expectedError: domain.ErrNoStops,
+		},
+		{
+			name:          "Error - More must visit districts than days",
+			req:           domain.ItineraryRequest{DateFrom: from, DateTo: to, MustVisit: []string{"Sylhet", "Bandarban", "Khulna"}},
+			expectedError: domain.ErrTooManyStops,
+		},
+		{
+			name: "Error - More districts than the itinerary cap",
+			req: domain.ItineraryRequest{DateFrom: from, DateTo: to, MustVisit: []string{"Sylhet"},
+				Optional: []string{"Bandarban", "Khulna", "Rajshahi", "Barishal", "Rangpur",
+					"Mymensingh", "Cumilla", "Bogura", "Jessore", "Dinajpur"}},
+			expectedError: domain.ErrTooManyCandidates,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewTravelUsecase(new(MockCache), new(MockDistrictRepository), nil)
+
+			_, err := usecase.Itinerary(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestCachedDaily(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	days := []string{"2024-05-01", "2024-05-02"}
+	entry := domain.DistrictCache{
+		Name:      "Sylhet",
+		UpdatedAt: now.Add(-10 * time.Minute),
+		Daily: map[string]*domain.TravelConditions{
+			"2024-05-01": {Temp2PM: 29, PM25: 40},
+			"2024-05-02": {Temp2PM: 31, PM25: 45},
+			"2024-05-03": {Temp2PM: 32, PM25: 50},
+		},
+	}
+
+	daily, ok := CachedDaily(entry, days, now, 90*time.Minute)
+	assert.True(t, ok)
+	assert.Len(t, daily, 2)
+	assert.Equal(t, 31.0, daily["2024-05-02"].Temp2PM)
+
+	_, ok = CachedDaily(entry, append(days, "2024-05-04"), now, 90*time.Minute)
+	assert.False(t, ok, "day outside the cached horizon")
+
+	_, ok = CachedDaily(entry, days, now.Add(2*time.Hour), 90*time.Minute)
+	assert.False(t, ok, "stale entry")
+
+	_, ok = CachedDaily(domain.DistrictCache{Name: "Khulna", UpdatedAt: now}, days, now, 90*time.Minute)
+	assert.False(t, ok, "entry without daily conditions")
+}
+
+func TestPlanRoute(t *testing.T) {
+	start := domain.Coordinate{Lat: 0, Long: 0}
+	// points on a line east of the start, handed over shuffled
+	stops := []*domain.District{
+		{Name: "C", Lat: 0, Long: 3},
+		{Name: "A", Lat: 0, Long: 1},
+		{Name: "D", Lat: 0, Long: 4},
+		{Name: "B", Lat: 0, Long: 2},
+	}
+
+	route := PlanRoute(start, stops)
+
+	var names []string
+	for _, d := range route {
+		names = append(names, d.Name)
+	}
+	assert.Equal(t, []string{"A", "B", "C", "D"}, names)
+	assert.Equal(t, "C", stops[0].Name, "input is left untouched")
+}
+
+func TestAssignDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		scores   [][]float64
+		expected []int
+	}{
+		{
+			name:     "Each stop on its best day",
+			scores:   [][]float64{{50, 80, 10, 10}, {10, 10, 10, 90}},
+			expected: []int{1, 3},
+		},
+		{
+			name:     "Order forces a compromise",
+			scores:   [][]float64{{10, 20, 90}, {90, 30, 10}},
+			expected: []int{0, 1},
+		},
+		{
+			name:     "As many stops as days",
+			scores:   [][]float64{{0, 100, 0}, {100, 0, 0}, {0, 0, 100}},
+			expected: []int{0, 1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, AssignDays(tt.scores))
+		})
+	}
+}
+
+func TestTravelUsecase_CoolestDistricts_FeelsLike(t *testing.T) {
+	mockCache := new(MockCache)
+	cached := []domain.DistrictCache{
+		// humid: cooler air but feels hotter
+		{Name: "Sylhet", Temp: 31, PM25: 30, ApparentTemp: floatPtr(37)},
+		{Name: "Rajshahi", Temp: 32, PM25: 40, ApparentTemp: floatPtr(33)},
+		// no apparent temperature from the provider
+		{Name: "Dhaka", Temp: 33, PM25: 80, HeatIndex: floatPtr(35)},
+	}
+	var names []string
+	for _, c := range cached {
+		c.Version = domain.DistrictCacheVersion
+		data, _ := json.Marshal(c)
+		mockCache.On("Get", mock.Anything, c.Name).Return(string(data), nil)
+		names = append(names, c.Name)
+	}
+	mockCache.On("Keys", mock.Anything).Return(names, nil)
+
+	usecase := NewTravelUsecase(mockCache, new(MockDistrictRepository), nil)
+
+	result, err := usecase.CoolestDistricts(context.Background(), &domain.CoolestCriteria{SortBy: domain.SortByFeelsLike})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 3) {
+		assert.Equal(t, "Rajshahi", result[0].Name)
+		assert.Equal(t, "Dhaka", result[1].Name)
+		assert.Equal(t, "Sylhet", result[2].Name)
+	}
+}
+
+func TestRankDistricts_RainTolerance(t *testing.T) {
+	districts := func() []domain.DistrictCache {
+		return []domain.DistrictCache{
+			{Name: "Sylhet", Temp: 28, PM25: 30, AvgPrecipitationSum: floatPtr(18)},
+			{Name: "Rajshahi", Temp: 32, PM25: 40, AvgPrecipitationSum: floatPtr(0.5)},
+			{Name: "Dhaka", Temp: 30, PM25: 80, AvgPrecipitationProbability: floatPtr(75)},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		tolerance string
+		expected  []string
+	}{
+		{name: "Low drops rainy districts", tolerance: domain.RainToleranceLow, expected: []string{"Rajshahi"}},
+		{name: "Medium ranks dry districts first", tolerance: domain.RainToleranceMedium, expected: []string{"Rajshahi", "Sylhet", "Dhaka"}},
+		{name: "Unset behaves like medium", expected: []string{"Rajshahi", "Sylhet", "Dhaka"}},
+		{name: "High ignores rain", tolerance: domain.RainToleranceHigh, expected: []string{"Sylhet", "Dhaka", "Rajshahi"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked := RankDistricts(districts(), domain.SortByCoolness, tt.tolerance)
+
+			names := make([]string, 0, len(ranked))
+			for _, d := range ranked {
+				names = append(names, d.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestTravelUsecase_CoolestDistricts_InvalidRainTolerance(t *testing.T) {
+	usecase := NewTravelUsecase(new(MockCache), new(MockDistrictRepository), nil)
+
+	_, err := usecase.CoolestDistricts(context.Background(), &domain.CoolestCriteria{RainTolerance: "none"})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRainTolerance)
+}
+
+func TestTravelUsecase_CoolestDistricts_IgnoresOtherCacheVersions(t *testing.T) {
+	mockCache := new(MockCache)
+	current, _ := json.Marshal(domain.DistrictCache{Version: domain.DistrictCacheVersion, Name: "Sylhet", Temp: 26.8})
+	// written before the cache was versioned
+	legacy := `{"Name":"Dhaka","AvgTemp2PM":20,"AvgPM25":40}`
+	mockCache.On("Keys", mock.Anything).Return([]string{"Sylhet", "Dhaka"}, nil)
+	mockCache.On("Get", mock.Anything, "Sylhet").Return(string(current), nil)
+	mockCache.On("Get", mock.Anything, "Dhaka").Return(legacy, nil)
+
+	usecase := NewTravelUsecase(mockCache, new(MockDistrictRepository), nil)
+
+	result, err := usecase.CoolestDistricts(context.Background(), &domain.CoolestCriteria{})
+
+	assert.NoError(t, err)
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "Sylhet", result[0].Name)
+	}
+}